@@ -0,0 +1,160 @@
+// Command evolve uses a genetic algorithm to search for starting patterns
+// that score well under a fitness function, running each candidate in a
+// headless game.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"gameoflife/game"
+	"log"
+	"math/rand"
+	"time"
+)
+
+// FitnessFunc scores a game after a candidate pattern has been run on it;
+// higher is better.
+type FitnessFunc func(*game.Game) float64
+
+// genome is a candidate starting pattern indexed as genome[y][x].
+type genome [][]bool
+
+var (
+	epochs         = flag.Int("epochs", 100, "number of generations of evolution")
+	populationSize = flag.Int("population", 100, "number of candidate patterns per generation of evolution")
+	size           = flag.Int("size", 16, "width and height of the candidate patterns")
+	steps          = flag.Int("steps", 100, "number of Life generations each candidate is run for")
+	mutationRate   = flag.Float64("mutation", 0.01, "probability of toggling each cell of a child")
+	tournamentSize = flag.Int("tournament", 3, "number of candidates competing to become a parent")
+	seed           = flag.Int64("seed", 0, "random seed; zero picks a time-based seed")
+)
+
+// maxPopulation rewards patterns that leave many live cells behind.
+func maxPopulation(g *game.Game) float64 {
+	return float64(g.Population())
+}
+
+func main() {
+	flag.Parse()
+	if *populationSize < 2 || *size < 1 || *epochs < 1 || *tournamentSize < 1 || *steps < 0 {
+		log.Fatal("epochs, population, size and tournament must be positive, population at least 2 and steps non-negative")
+	}
+	s := *seed
+	if s == 0 {
+		s = time.Now().UnixNano()
+	}
+	evolve(rand.New(rand.NewSource(s)), maxPopulation)
+}
+
+// evolve runs the genetic algorithm and returns the best fitness of every
+// epoch.
+func evolve(rng *rand.Rand, fitness FitnessFunc) []float64 {
+	g := game.NewFromOptions(game.Options{CellSize: game.MinCellSize})
+	defer g.Close()
+	evaluate := func(candidate genome) float64 {
+		if err := g.LoadPattern(candidate.pattern(), game.LoadOptions{}); err != nil {
+			log.Fatal(err)
+		}
+		for range *steps {
+			g.Step()
+		}
+		return fitness(g)
+	}
+
+	candidates := make([]genome, *populationSize)
+	for i := range candidates {
+		candidates[i] = randomGenome(rng, *size)
+	}
+	scores := make([]float64, len(candidates))
+	bests := make([]float64, 0, *epochs)
+	for epoch := 1; epoch <= *epochs; epoch++ {
+		best := 0
+		for i, candidate := range candidates {
+			scores[i] = evaluate(candidate)
+			if scores[i] > scores[best] {
+				best = i
+			}
+		}
+		bests = append(bests, scores[best])
+		if epoch%10 == 0 || epoch == *epochs {
+			fmt.Printf("epoch %d: best fitness %g\n%s\n", epoch, scores[best], game.EncodeRLE(candidates[best].pattern()))
+		}
+		// The best candidate survives unchanged so that the best fitness
+		// never decreases.
+		next := []genome{candidates[best]}
+		for len(next) < len(candidates) {
+			a := tournament(rng, candidates, scores)
+			b := tournament(rng, candidates, scores)
+			child := crossover(rng, a, b)
+			mutate(rng, child, *mutationRate)
+			next = append(next, child)
+		}
+		candidates = next
+	}
+	return bests
+}
+
+func randomGenome(rng *rand.Rand, size int) genome {
+	cells := make(genome, size)
+	for y := range cells {
+		cells[y] = make([]bool, size)
+		for x := range cells[y] {
+			cells[y][x] = rng.Intn(2) == 1
+		}
+	}
+	return cells
+}
+
+// tournament picks the fittest of a few random candidates.
+func tournament(rng *rand.Rand, candidates []genome, scores []float64) genome {
+	winner := rng.Intn(len(candidates))
+	for range *tournamentSize - 1 {
+		if i := rng.Intn(len(candidates)); scores[i] > scores[winner] {
+			winner = i
+		}
+	}
+	return candidates[winner]
+}
+
+// crossover copies a with a random rectangular region taken from b.
+func crossover(rng *rand.Rand, a, b genome) genome {
+	size := len(a)
+	x0, x1 := randomSpan(rng, size)
+	y0, y1 := randomSpan(rng, size)
+	child := make(genome, size)
+	for y := range child {
+		child[y] = append([]bool(nil), a[y]...)
+		if y >= y0 && y < y1 {
+			copy(child[y][x0:x1], b[y][x0:x1])
+		}
+	}
+	return child
+}
+
+func randomSpan(rng *rand.Rand, size int) (int, int) {
+	start, end := rng.Intn(size+1), rng.Intn(size+1)
+	return min(start, end), max(start, end)
+}
+
+func mutate(rng *rand.Rand, cells genome, rate float64) {
+	for y := range cells {
+		for x := range cells[y] {
+			if rng.Float64() < rate {
+				cells[y][x] = !cells[y][x]
+			}
+		}
+	}
+}
+
+func (cells genome) pattern() *game.Pattern {
+	p := &game.Pattern{Cells: make([][]uint8, len(cells))}
+	for y, row := range cells {
+		p.Cells[y] = make([]uint8, len(row))
+		for x, alive := range row {
+			if alive {
+				p.Cells[y][x] = 1
+			}
+		}
+	}
+	return p
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"gameoflife/game"
+	"math/rand"
+	"testing"
+)
+
+// setFlag sets a flag value for the rest of the test.
+func setFlag[T any](t *testing.T, p *T, value T) {
+	old := *p
+	*p = value
+	t.Cleanup(func() { *p = old })
+}
+
+func filled(size int, alive bool) genome {
+	cells := make(genome, size)
+	for y := range cells {
+		cells[y] = make([]bool, size)
+		for x := range cells[y] {
+			cells[y][x] = alive
+		}
+	}
+	return cells
+}
+
+// TestCrossover checks that a child is a copy of a with a rectangle
+// within the grid, possibly empty, taken from b.
+func TestCrossover(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const size = 8
+	a, b := filled(size, false), filled(size, true)
+	for range 200 {
+		child := crossover(rng, a, b)
+		if len(child) != size {
+			t.Fatalf("child has %d rows, want %d", len(child), size)
+		}
+		x0, y0, x1, y1 := size, size, -1, -1
+		count := 0
+		for y, row := range child {
+			if len(row) != size {
+				t.Fatalf("child row %d has %d cells, want %d", y, len(row), size)
+			}
+			for x, alive := range row {
+				if alive {
+					x0, y0, x1, y1 = min(x0, x), min(y0, y), max(x1, x), max(y1, y)
+					count++
+				}
+			}
+		}
+		if count > 0 && count != (x1-x0+1)*(y1-y0+1) {
+			t.Fatalf("cells taken from b do not form a rectangle:\n%s", game.EncodeRLE(child.pattern()))
+		}
+		child[0][0] = !child[0][0]
+		if a[0][0] || !b[0][0] {
+			t.Fatal("child shares its cells with a parent")
+		}
+	}
+}
+
+func TestTournament(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	candidates := []genome{filled(1, false), filled(1, true), filled(1, false)}
+	scores := []float64{1, 3, 2}
+	setFlag(t, tournamentSize, 50)
+	for range 20 {
+		if winner := tournament(rng, candidates, scores); !winner[0][0] {
+			t.Fatal("a large tournament did not pick the fittest candidate")
+		}
+	}
+}
+
+func TestMutate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	cells := filled(4, false)
+	for _, tt := range []struct {
+		rate float64
+		want bool
+	}{{0, false}, {1, true}} {
+		mutate(rng, cells, tt.rate)
+		for y, row := range cells {
+			for x, alive := range row {
+				if alive != tt.want {
+					t.Fatalf("rate %g left cell (%d, %d) %t", tt.rate, x, y, alive)
+				}
+			}
+		}
+	}
+}
+
+// TestEvolveKeepsBestFitness checks the elitism guarantee: the best
+// candidate is carried over unchanged, so the best fitness never drops.
+func TestEvolveKeepsBestFitness(t *testing.T) {
+	setFlag(t, epochs, 8)
+	setFlag(t, populationSize, 8)
+	setFlag(t, size, 6)
+	setFlag(t, steps, 10)
+	setFlag(t, mutationRate, 0.2)
+	bests := evolve(rand.New(rand.NewSource(1)), maxPopulation)
+	if len(bests) != *epochs {
+		t.Fatalf("evolve reported %d epochs, want %d", len(bests), *epochs)
+	}
+	for epoch := 1; epoch < len(bests); epoch++ {
+		if bests[epoch] < bests[epoch-1] {
+			t.Errorf("best fitness dropped from %g to %g in epoch %d", bests[epoch-1], bests[epoch], epoch+1)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"math"
+)
+
+// ageBuckets are the upper bounds of the lifespan histogram's buckets; the
+// last bucket collects every older cell.
+var ageBuckets = []int{1, 2, 4, 8, 16, 32}
+
+// CellAge is the number of consecutive generations the cell at (x, y) has
+// been alive for, counting the current one, or 0 for a dead cell. Cells that
+// were drawn or loaded are one generation old.
+func (g *Game) CellAge(x, y int) int {
+	if !g.currentGrid[x][y] {
+		return 0
+	}
+	return int(g.ages[x][y]) + 1
+}
+
+// nextAge is the age counter of the cell at (x, y) once the next
+// generation, in which it is alive or not, has been computed.
+func (g *Game) nextAge(x, y int, next bool) uint16 {
+	if !g.currentGrid[x][y] || !next {
+		return 0
+	}
+	return min(g.ages[x][y], math.MaxUint16-1) + 1
+}
+
+// ageHistogram counts the live cells per age bucket.
+func (g *Game) ageHistogram() []int {
+	counts := make([]int, len(ageBuckets)+1)
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			age := g.CellAge(i, j)
+			if age == 0 {
+				continue
+			}
+			bucket := len(ageBuckets)
+			for b, bound := range ageBuckets {
+				if age <= bound {
+					bucket = b
+					break
+				}
+			}
+			counts[bucket]++
+		}
+	}
+	return counts
+}
+
+const (
+	histogramBarWidth  = 32
+	histogramBarHeight = 80
+	histogramMargin    = 16
+)
+
+// drawAgeHistogram draws the lifespan histogram in the bottom right corner.
+// It is recounted once per generation.
+func (g *Game) drawAgeHistogram(screen *ebiten.Image) {
+	if !g.showAgeHistogram || g.mode != ModeLife {
+		return
+	}
+	if g.histogram == nil || g.histogramGeneration != g.generation {
+		g.histogram, g.histogramGeneration = g.ageHistogram(), g.generation
+	}
+	most := 1
+	for _, count := range g.histogram {
+		most = max(most, count)
+	}
+	theme := g.theme()
+	bounds := screen.Bounds()
+	left := bounds.Dx() - histogramMargin - len(g.histogram)*histogramBarWidth
+	bottom := g.screenHeight - histogramMargin - 16
+	vector.DrawFilledRect(screen, float32(left-4), float32(bottom-histogramBarHeight-20), float32(len(g.histogram)*histogramBarWidth+8), float32(histogramBarHeight+40), theme.BackgroundColor, false)
+	g.drawLabel(screen, g.t("Cell ages"), float64(left), float64(bottom-histogramBarHeight-20))
+	for b, count := range g.histogram {
+		height := float32(histogramBarHeight * count / most)
+		x := float32(left + b*histogramBarWidth)
+		vector.DrawFilledRect(screen, x+2, float32(bottom)-height, histogramBarWidth-4, height, theme.CellColor, false)
+		ebitenutil.DebugPrintAt(screen, ageBucketLabel(b), left+b*histogramBarWidth, bottom)
+	}
+}
+
+func ageBucketLabel(bucket int) string {
+	if bucket == len(ageBuckets) {
+		return fmt.Sprintf("%d+", ageBuckets[bucket-1]+1)
+	}
+	if bucket == 0 || ageBuckets[bucket-1]+1 == ageBuckets[bucket] {
+		return fmt.Sprint(ageBuckets[bucket])
+	}
+	return fmt.Sprintf("%d-%d", ageBuckets[bucket-1]+1, ageBuckets[bucket])
+}
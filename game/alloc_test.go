@@ -0,0 +1,65 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func newRandomGame(tb testing.TB, options Options) *Game {
+	g := NewFromOptions(options)
+	tb.Cleanup(g.Close)
+	g.Randomize(SymmetryNone)
+	return g
+}
+
+// TestCycleDoesNotAllocate guards the double-buffered grids: a Life
+// generation is computed entirely in buffers preallocated on the Game.
+func TestCycleDoesNotAllocate(t *testing.T) {
+	g := newRandomGame(t, Options{CellSize: 10, Seed: 1})
+	if allocs := testing.AllocsPerRun(100, g.cycle); allocs != 0 {
+		t.Errorf("cycle made %g allocations per call, want 0", allocs)
+	}
+}
+
+// TestPreviewDoesNotAllocate guards the rule preview, which is computed
+// every frame while it is shown, into the grid kept on the Game for it.
+func TestPreviewDoesNotAllocate(t *testing.T) {
+	g := newRandomGame(t, Options{CellSize: 10, Seed: 1})
+	allocs := testing.AllocsPerRun(100, func() { g.previewInto(&g.previewGrid, HighLife) })
+	if allocs != 0 {
+		t.Errorf("previewInto made %g allocations per call, want 0", allocs)
+	}
+}
+
+func BenchmarkCycleAllocs(b *testing.B) {
+	g := newRandomGame(b, Options{CellSize: 10, Topology: TopologyToroidal, Seed: 1})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		g.cycle()
+	}
+}
+
+func BenchmarkStepAllocs(b *testing.B) {
+	g := newRandomGame(b, Options{CellSize: 10, Topology: TopologyToroidal, Seed: 1})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		g.Step()
+	}
+}
+
+func BenchmarkDrawGrid(b *testing.B) {
+	g := newRandomGame(b, Options{CellSize: 10, Seed: 1})
+	screen := ebiten.NewImage(ScreenWidth, ScreenHeight)
+	defer screen.Deallocate()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		g.drawGrid(screen)
+		// Reading a pixel flushes the queued draw commands, so that the
+		// GPU work is measured and the queue does not keep growing.
+		screen.At(0, 0)
+	}
+}
@@ -0,0 +1,90 @@
+// Package analysis runs patterns headlessly to find out how they evolve.
+package analysis
+
+import (
+	"gameoflife/game"
+)
+
+type PatternClass int
+
+const (
+	PatternUnclassified PatternClass = iota
+	PatternDying
+	PatternStable
+	PatternOscillating
+	PatternGrowing
+)
+
+func (c PatternClass) String() string {
+	switch c {
+	case PatternDying:
+		return "Dying"
+	case PatternStable:
+		return "Stable"
+	case PatternOscillating:
+		return "Oscillating"
+	case PatternGrowing:
+		return "Growing"
+	default:
+		return "Unclassified"
+	}
+}
+
+// growthWindow is the number of consecutive generations the bounding box
+// must keep growing for a pattern to count as growing.
+const growthWindow = 50
+
+// ClassifyPattern runs pattern, indexed as pattern[y][x], under Conway's
+// rules on a bounded grid for up to maxGen generations. A pattern that dies
+// out is dying, one that repeats a previous generation is stable or
+// oscillating depending on the period, and one whose bounding box never
+// shrinks and ends up larger over growthWindow generations is growing.
+// Patterns that fit none of these, or that do not fit on the grid, are
+// unclassified.
+func ClassifyPattern(pattern [][]bool, maxGen int) PatternClass {
+	g := game.NewFromOptions(game.Options{CellSize: game.MinCellSize})
+	defer g.Close()
+	p := &game.Pattern{Cells: make([][]uint8, len(pattern))}
+	for y, row := range pattern {
+		p.Cells[y] = make([]uint8, len(row))
+		for x, alive := range row {
+			if alive {
+				p.Cells[y][x] = 1
+			}
+		}
+	}
+	if err := g.LoadPattern(p, game.LoadOptions{}); err != nil {
+		return PatternUnclassified
+	}
+	seen := map[string]int{string(g.MarshalGrid()): 0}
+	var areas []int
+	for generation := 1; generation <= maxGen; generation++ {
+		g.Step()
+		if g.Population() == 0 {
+			return PatternDying
+		}
+		grid := string(g.MarshalGrid())
+		if previous, ok := seen[grid]; ok {
+			if generation-previous == 1 {
+				return PatternStable
+			}
+			return PatternOscillating
+		}
+		seen[grid] = generation
+		width, height := g.BoundingBoxSize()
+		areas = append(areas, width*height)
+		if len(areas) > growthWindow && growing(areas[len(areas)-growthWindow-1:]) {
+			return PatternGrowing
+		}
+	}
+	return PatternUnclassified
+}
+
+func growing(areas []int) bool {
+	for i := 1; i < len(areas); i++ {
+		if areas[i] < areas[i-1] {
+			return false
+		}
+	}
+	return areas[len(areas)-1] > areas[0]
+}
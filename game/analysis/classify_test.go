@@ -0,0 +1,46 @@
+package analysis
+
+import "testing"
+
+// parse turns rows of '.' and 'O' into a pattern.
+func parse(rows ...string) [][]bool {
+	pattern := make([][]bool, len(rows))
+	for y, row := range rows {
+		pattern[y] = make([]bool, len(row))
+		for x, c := range row {
+			pattern[y][x] = c == 'O'
+		}
+	}
+	return pattern
+}
+
+func TestClassifyPattern(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		pattern [][]bool
+		maxGen  int
+		want    PatternClass
+	}{
+		{"lone cell", parse("O"), 10, PatternDying},
+		{"block", parse("OO", "OO"), 10, PatternStable},
+		{"blinker", parse("OOO"), 10, PatternOscillating},
+		{"glider gun", parse(
+			"........................O...........",
+			"......................O.O...........",
+			"............OO......OO............OO",
+			"...........O...O....OO............OO",
+			"OO........O.....O...OO..............",
+			"OO........O...O.OO....O.O...........",
+			"..........O.....O.......O...........",
+			"...........O...O....................",
+			"............OO......................",
+		), 200, PatternGrowing},
+		{"R-pentomino, cut short", parse(".OO", "OO.", ".O."), 10, PatternUnclassified},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyPattern(tt.pattern, tt.maxGen); got != tt.want {
+				t.Errorf("ClassifyPattern = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
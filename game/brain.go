@@ -0,0 +1,100 @@
+package game
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// Brian's Brain cell states. A cell that is off fires when exactly two of
+// its neighbors are firing, firing cells start dying and dying cells turn
+// off.
+const (
+	BrainOff uint8 = iota
+	BrainFiring
+	BrainDying
+	brainStates
+)
+
+func (g *Game) cycleBriansBrain() {
+	newStates := g.nextStates()
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			switch g.states[i][j] {
+			case BrainFiring:
+				newStates[i][j] = BrainDying
+			case BrainOff:
+				if g.countNeighborsInState(i, j, BrainFiring) == 2 {
+					newStates[i][j] = BrainFiring
+				} else {
+					newStates[i][j] = BrainOff
+				}
+			default:
+				newStates[i][j] = BrainOff
+			}
+		}
+	}
+	g.states = newStates
+}
+
+func (g *Game) countNeighborsInState(x, y int, state uint8) int {
+	count := 0
+	for i := -1; i <= 1; i++ {
+		for j := -1; j <= 1; j++ {
+			if i == 0 && j == 0 {
+				continue
+			}
+			if nx, ny, ok := g.neighbor(x, y, i, j); ok && g.states[nx][ny] == state {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func (g *Game) explainBriansBrain(x, y int) string {
+	switch g.states[x][y] {
+	case BrainFiring:
+		return g.t("firing -> starts dying")
+	case BrainDying:
+		return g.t("dying -> turns off")
+	default:
+		count := g.countNeighborsInState(x, y, BrainFiring)
+		if count == 2 {
+			return g.t("off with 2 firing neighbors -> fires")
+		}
+		return fmt.Sprintf(g.t("off with %d firing neighbors -> stays off"), count)
+	}
+}
+
+// multiState reports whether the mode keeps its cells in g.states rather
+// than in the Life grid.
+func (g *Game) multiState() bool {
+	return g.mode == ModeWireWorld || g.mode == ModeBriansBrain
+}
+
+// stateCount is the number of states of a multi-state mode.
+func (g *Game) stateCount() uint8 {
+	if g.mode == ModeBriansBrain {
+		return brainStates
+	}
+	return wireStates
+}
+
+// stateColor is the color of a non-empty cell of a multi-state mode. Brian's
+// Brain themes have no state colors of their own; dying cells are drawn
+// halfway between the cell and background colors.
+func (g *Game) stateColor(theme *Theme, state uint8) color.Color {
+	if g.mode != ModeBriansBrain {
+		return theme.stateColor(state)
+	}
+	if state == BrainDying {
+		return mixColors(theme.CellColor, theme.BackgroundColor)
+	}
+	return theme.CellColor
+}
+
+func mixColors(a, b color.Color) color.Color {
+	ar, ag, ab, _ := a.RGBA()
+	br, bg, bb, _ := b.RGBA()
+	return color.RGBA64{R: uint16((ar + br) / 2), G: uint16((ag + bg) / 2), B: uint16((ab + bb) / 2), A: 0xffff}
+}
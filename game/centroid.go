@@ -0,0 +1,73 @@
+package game
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// centroidTracker follows LiveCentroid from one generation to the next.
+type centroidTracker struct {
+	x, y   float64
+	ok     bool
+	vx, vy float64
+}
+
+// LiveCentroid returns the mean cell coordinates of the live cells, each
+// weighted by its age when Options.AgeWeightedCentroid is set. ok is false
+// when no cells are alive.
+func (g *Game) LiveCentroid() (x, y float64, ok bool) {
+	ageWeighted := g.ageWeightedCentroid && g.mode == ModeLife
+	var sumX, sumY, total float64
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if !g.isAlive(i, j) {
+				continue
+			}
+			weight := 1.0
+			if ageWeighted {
+				weight = float64(g.CellAge(i, j))
+			}
+			sumX, sumY, total = sumX+weight*float64(i), sumY+weight*float64(j), total+weight
+		}
+	}
+	if total == 0 {
+		return 0, 0, false
+	}
+	return sumX / total, sumY / total, true
+}
+
+// trackCentroid updates the centroid and its velocity after a generation.
+// The velocity is only known once two generations in a row have live cells.
+func (g *Game) trackCentroid() {
+	x, y, ok := g.LiveCentroid()
+	if ok && g.centroid.ok {
+		g.centroid.vx, g.centroid.vy = x-g.centroid.x, y-g.centroid.y
+	} else {
+		g.centroid.vx, g.centroid.vy = 0, 0
+	}
+	g.centroid.x, g.centroid.y, g.centroid.ok = x, y, ok
+}
+
+// centroidArm is the length of each arm of the centroid crosshair, in cells.
+const centroidArm = 2
+
+// drawCentroid marks the centroid with a crosshair.
+func (g *Game) drawCentroid(dst *ebiten.Image) {
+	if !g.centroid.ok {
+		return
+	}
+	size := g.drawCellSize()
+	x, y := (float32(g.centroid.x)+0.5)*size, (float32(g.centroid.y)+0.5)*size
+	arm := centroidArm * size
+	c := contrastingColor(g.theme().BackgroundColor)
+	vector.StrokeLine(dst, x-arm, y, x+arm, y, 2, c, true)
+	vector.StrokeLine(dst, x, y-arm, x, y+arm, 2, c, true)
+}
+
+func (g *Game) centroidDescription() string {
+	if !g.centroid.ok {
+		return g.t("none")
+	}
+	return fmt.Sprintf("(%.1f, %.1f), %s (%+.2f, %+.2f)/%s", g.centroid.x, g.centroid.y, g.t("velocity"), g.centroid.vx, g.centroid.vy, g.t("gen"))
+}
@@ -0,0 +1,17 @@
+//go:build !js
+
+package game
+
+import "github.com/atotto/clipboard"
+
+// readClipboard reads the system clipboard in the background and reports the
+// result through done.
+func readClipboard(done func(text string, err error)) {
+	go func() {
+		done(clipboard.ReadAll())
+	}()
+}
+
+func writeClipboard(text string) error {
+	return clipboard.WriteAll(text)
+}
@@ -0,0 +1,45 @@
+//go:build js
+
+package game
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// readClipboard asks the browser for the clipboard contents and reports the
+// result through done once the returned promise settles.
+func readClipboard(done func(text string, err error)) {
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if !clipboard.Truthy() {
+		done("", errors.New("clipboard is not available"))
+		return
+	}
+	var resolve, reject js.Func
+	release := func() {
+		resolve.Release()
+		reject.Release()
+	}
+	resolve = js.FuncOf(func(this js.Value, args []js.Value) any {
+		defer release()
+		done(args[0].String(), nil)
+		return nil
+	})
+	reject = js.FuncOf(func(this js.Value, args []js.Value) any {
+		defer release()
+		done("", errors.New(args[0].Call("toString").String()))
+		return nil
+	})
+	clipboard.Call("readText").Call("then", resolve, reject)
+}
+
+// writeClipboard hands text to the browser, which writes it asynchronously;
+// a failed write is only reported in the console.
+func writeClipboard(text string) error {
+	clipboard := js.Global().Get("navigator").Get("clipboard")
+	if !clipboard.Truthy() {
+		return errors.New("clipboard is not available")
+	}
+	clipboard.Call("writeText", text)
+	return nil
+}
@@ -0,0 +1,107 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"maps"
+	"math/rand"
+	"slices"
+)
+
+// countingSource counts the values drawn from a seeded source, so that a
+// clone can replay them to continue from the same point of the sequence.
+type countingSource struct {
+	rand.Source64
+	seed  int64
+	draws uint64
+}
+
+func newCountingSource(seed int64) *countingSource {
+	return &countingSource{Source64: rand.NewSource(seed).(rand.Source64), seed: seed}
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.Source64.Int63()
+}
+
+func (s *countingSource) Uint64() uint64 {
+	s.draws++
+	return s.Source64.Uint64()
+}
+
+func (s *countingSource) clone() *countingSource {
+	c := newCountingSource(s.seed)
+	for c.draws < s.draws {
+		c.Uint64()
+	}
+	return c
+}
+
+// Clone returns a copy of g that can be stepped and edited without affecting
+// g: the grids and cell states, the rule, theme and options, the undo
+// snapshots and the population and period history are all copied, and the
+// clone draws the same random numbers g would from here on. Recordings, the
+// journal, sound and auto-persisting stay with g. The clone has its own
+// cycle worker pool, so it should be closed as well.
+func (g *Game) Clone() *Game {
+	c := new(Game)
+	*c = *g
+	c.currentGrid = &c.gridA
+	if g.currentGrid == &g.gridB {
+		c.currentGrid = &c.gridB
+	}
+	c.states = &c.statesA
+	if g.states == &g.statesB {
+		c.states = &c.statesB
+	}
+	c.rngSource = g.rngSource.clone()
+	c.rng = rand.New(c.rngSource)
+	c.entry = numericEntry{}
+	c.pastes = make(chan clipboardResult, 1)
+	c.pool = newCyclePool(c.workers, c.cycleLifeBand)
+	c.sound, c.recorder, c.history, c.journal = nil, nil, nil, nil
+	c.autoPersistPath = ""
+
+	c.keyBindings = maps.Clone(g.keyBindings)
+	c.lockedActions = maps.Clone(g.lockedActions)
+	if g.locale != nil {
+		c.locale = &Locale{Translations: maps.Clone(g.locale.Translations)}
+	}
+	c.themes = make([]*Theme, len(g.themes))
+	for i, theme := range g.themes {
+		c.themes[i] = theme.clone()
+	}
+	if g.invertedTheme != nil {
+		c.invertedTheme = g.invertedTheme.clone()
+	}
+	c.zones = slices.Clone(g.zones)
+	c.zoneTables = slices.Clone(g.zoneTables)
+	c.scenarios = slices.Clone(g.scenarios)
+	c.histogram = slices.Clone(g.histogram)
+	c.periods.hashes = slices.Clone(g.periods.hashes)
+	if g.playback != nil {
+		c.playback = make([][]byte, len(g.playback))
+		for i, frame := range g.playback {
+			c.playback[i] = slices.Clone(frame)
+		}
+	}
+	c.undoStack = make([]undoSnapshot, len(g.undoStack))
+	for i, snapshot := range g.undoStack {
+		snapshot.grid = slices.Clone(snapshot.grid)
+		if snapshot.states != nil {
+			states := *snapshot.states
+			snapshot.states = &states
+		}
+		c.undoStack[i] = snapshot
+	}
+
+	// The rendering caches are rebuilt on the clone's first Draw.
+	c.sprites, c.hexOutline = cellSprites{}, hexOutline{}
+	c.frameCache, c.tooltip, c.textBuffer = nil, nil, nil
+	c.lastRenderedGeneration = -1
+	if g.renderBuffer != nil {
+		bounds := g.renderBuffer.Bounds()
+		c.renderBuffer = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+	return c
+}
@@ -0,0 +1,31 @@
+package game
+
+import "testing"
+
+// TestCloneIsIndependent checks that a clone of a fresh game can be
+// stepped, and that neither its grid nor its random numbers are shared with
+// the original.
+func TestCloneIsIndependent(t *testing.T) {
+	g := newRandomGame(t, Options{CellSize: 10, Seed: 1})
+	want := *g.currentGrid
+	c := g.Clone()
+	t.Cleanup(c.Close)
+
+	c.Step()
+	if c.generation != 1 {
+		t.Errorf("clone is at generation %d after a step, want 1", c.generation)
+	}
+	if *g.currentGrid != want {
+		t.Error("stepping the clone changed the original's grid")
+	}
+	g.Step()
+	if *g.currentGrid != *c.currentGrid {
+		t.Error("original and clone differ after the same step")
+	}
+
+	first := c.rng.Int63()
+	c.rng.Int63()
+	if got := g.rng.Int63(); got != first {
+		t.Errorf("original drew %d after the clone drew twice, want the clone's first draw %d", got, first)
+	}
+}
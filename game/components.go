@@ -0,0 +1,77 @@
+package game
+
+import (
+	"image/color"
+)
+
+// componentPalette is cycled through by component index so that touching
+// components rarely share a color.
+var componentPalette = []color.RGBA{
+	{0xe6, 0x19, 0x4b, 0xff},
+	{0x3c, 0xb4, 0x4b, 0xff},
+	{0xff, 0xe1, 0x19, 0xff},
+	{0x43, 0x63, 0xd8, 0xff},
+	{0xf5, 0x82, 0x31, 0xff},
+	{0x91, 0x1e, 0xb4, 0xff},
+	{0x42, 0xd4, 0xf4, 0xff},
+	{0xf0, 0x32, 0xe6, 0xff},
+	{0xbf, 0xef, 0x45, 0xff},
+	{0x46, 0x99, 0x90, 0xff},
+}
+
+var (
+	fourConnected  = [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+	eightConnected = [][2]int{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}}
+)
+
+// cycleComponentColoring switches between no component coloring, 8-connected
+// and 4-connected components.
+func (g *Game) cycleComponentColoring() {
+	switch g.componentConnectivity {
+	case 0:
+		g.componentConnectivity = 8
+	case 8:
+		g.componentConnectivity = 4
+	default:
+		g.componentConnectivity = 0
+	}
+}
+
+// labelComponents flood fills the live cells into g.componentLabels, giving
+// every connected component its own label starting at 1, and returns the
+// number of components. Components connect across the wrapped edges.
+func (g *Game) labelComponents() int {
+	offsets := eightConnected
+	if g.componentConnectivity == 4 {
+		offsets = fourConnected
+	}
+	g.componentLabels = [maxColumns][maxRows]int32{}
+	var stack [][2]int
+	components := int32(0)
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if !g.currentGrid[i][j] || g.componentLabels[i][j] != 0 {
+				continue
+			}
+			components++
+			g.componentLabels[i][j] = components
+			stack = append(stack[:0], [2]int{i, j})
+			for len(stack) > 0 {
+				cell := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				for _, offset := range offsets {
+					x, y, ok := g.neighbor(cell[0], cell[1], offset[0], offset[1])
+					if ok && g.currentGrid[x][y] && g.componentLabels[x][y] == 0 {
+						g.componentLabels[x][y] = components
+						stack = append(stack, [2]int{x, y})
+					}
+				}
+			}
+		}
+	}
+	return int(components)
+}
+
+func componentColor(label int32) color.Color {
+	return componentPalette[int(label-1)%len(componentPalette)]
+}
@@ -0,0 +1,21 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// SaveCoordinates writes one "x,y" line per live cell in the active
+// columns and rows, sorted by x and then y, for spreadsheets and scripts.
+func (g *Game) SaveCoordinates(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if g.isAlive(i, j) {
+				fmt.Fprintf(b, "%d,%d\n", i, j)
+			}
+		}
+	}
+	return b.Flush()
+}
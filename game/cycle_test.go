@@ -0,0 +1,37 @@
+package game
+
+import "testing"
+
+// referenceCycle computes the next generation the straightforward way, into
+// a fresh grid and without the rule table or the worker pool.
+func referenceCycle(g *Game) Grid {
+	var next Grid
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			count := g.countLiveNeighbors(i, j)
+			if g.currentGrid[i][j] {
+				next[i][j] = g.rule.Survive[count]
+			} else {
+				next[i][j] = g.rule.Born[count]
+			}
+		}
+	}
+	return next
+}
+
+func TestCycleMatchesReference(t *testing.T) {
+	for _, topology := range []Topology{TopologyBounded, TopologyToroidal} {
+		t.Run(topology.String(), func(t *testing.T) {
+			g := NewFromOptions(Options{CellSize: 10, Topology: topology, Seed: 1})
+			defer g.Close()
+			g.Randomize(SymmetryNone)
+			for generation := 1; generation <= 100; generation++ {
+				want := referenceCycle(g)
+				g.Step()
+				if *g.currentGrid != want {
+					t.Fatalf("generation %d differs from the reference", generation)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package game
+
+// cycleElementary computes the next generation of the 1D automaton from the
+// last drawn row. The nth row is drawn on row n until the diagram reaches the
+// bottom of the screen, after which it scrolls up one row per generation.
+func (g *Game) cycleElementary() {
+	g.elementaryRow++
+	row := g.elementaryRow
+	if row >= g.rows {
+		g.elementaryRow = g.rows - 1
+		row = g.rows - 1
+		for i := 0; i < g.columns; i++ {
+			copy(g.currentGrid[i][:row], g.currentGrid[i][1:row+1])
+		}
+	}
+	previous := row - 1
+	for i := 0; i < g.columns; i++ {
+		left := g.currentGrid[(i-1+g.columns)%g.columns][previous]
+		center := g.currentGrid[i][previous]
+		right := g.currentGrid[(i+1)%g.columns][previous]
+		neighborhood := b2i(left)<<2 | b2i(center)<<1 | b2i(right)
+		g.currentGrid[i][row] = g.elementaryRule>>neighborhood&1 == 1
+	}
+}
+
+func (g *Game) toggleElementarySeed(x int) {
+	g.elementarySeed[x] = !g.elementarySeed[x]
+	g.restartElementary()
+}
+
+func (g *Game) restartElementary() {
+	*g.currentGrid = Grid{}
+	for i := 0; i < g.columns; i++ {
+		g.currentGrid[i][0] = g.elementarySeed[i]
+	}
+	g.generation = 0
+	g.elementaryRow = 0
+	g.ticks = 0
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,48 @@
+package game
+
+import (
+	"strconv"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// numericEntry is a small prompt that collects digits from the keyboard and
+// hands the parsed value to apply once Enter is pressed.
+type numericEntry struct {
+	active bool
+	label  string
+	digits []rune
+	apply  func(n int)
+}
+
+func (e *numericEntry) open(label string, apply func(n int)) {
+	e.active = true
+	e.label = label
+	e.digits = e.digits[:0]
+	e.apply = apply
+}
+
+func (e *numericEntry) update() {
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if r >= '0' && r <= '9' && len(e.digits) < 9 {
+			e.digits = append(e.digits, r)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(e.digits) > 0 {
+		e.digits = e.digits[:len(e.digits)-1]
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		e.active = false
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) {
+		e.active = false
+		if n, err := strconv.Atoi(string(e.digits)); err == nil {
+			e.apply(n)
+		}
+	}
+}
+
+func (e *numericEntry) String() string {
+	return e.label + ": " + string(e.digits) + "_"
+}
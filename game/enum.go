@@ -0,0 +1,38 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// textEnum is an enumeration numbered from 0 whose String names its values,
+// as Mode and Topology are. They are marshaled by name so that scenario
+// files stay readable and keep working when values are added.
+type textEnum interface {
+	~int
+	String() string
+}
+
+// enumText is MarshalText for a value of an enumeration with count values.
+func enumText[E textEnum](v E, count E) ([]byte, error) {
+	if v < 0 || v >= count {
+		return nil, fmt.Errorf("cannot marshal unknown %T %d", v, int(v))
+	}
+	return []byte(v.String()), nil
+}
+
+// parseEnumText is UnmarshalText for an enumeration with count values. Names
+// are matched case-insensitively.
+func parseEnumText[E textEnum](v *E, text []byte, count E) error {
+	for value := E(0); value < count; value++ {
+		if strings.EqualFold(value.String(), string(text)) {
+			*v = value
+			return nil
+		}
+	}
+	names := make([]string, count)
+	for value := E(0); value < count; value++ {
+		names[value] = fmt.Sprintf("%q", value.String())
+	}
+	return fmt.Errorf("unknown %T %q, want one of %s", *v, text, strings.Join(names, ", "))
+}
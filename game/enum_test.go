@@ -0,0 +1,35 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptionsEnumsByName(t *testing.T) {
+	var opts Options
+	data := `{"Mode": "brian's brain", "Topology": "Toroidal", "GridTopology": "Hex", "RandomMode": "Uniform"}`
+	if err := json.Unmarshal([]byte(data), &opts); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if opts.Mode != ModeBriansBrain || opts.Topology != TopologyToroidal || opts.GridTopology != TopologyHex || opts.RandomMode != RandomUniform {
+		t.Errorf("got %s, %s, %s and %s", opts.Mode, opts.Topology, opts.GridTopology, opts.RandomMode)
+	}
+	for _, data := range []string{`{"Mode": "Sand"}`, `{"Topology": 1}`} {
+		if err := json.Unmarshal([]byte(data), &opts); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", data)
+		}
+	}
+	if _, err := json.Marshal(modeCount); err == nil {
+		t.Error("Marshal succeeded for an unknown mode")
+	}
+}
+
+// TestBuiltinScenariosParse guards the built-in scenarios against renamed
+// enumeration values.
+func TestBuiltinScenariosParse(t *testing.T) {
+	for _, name := range ScenarioNames() {
+		if _, err := readScenario(name); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package game
+
+const (
+	// defaultPlateauWindow is the number of generations IsStablePopulation
+	// looks at in the HUD unless Options.PlateauWindow says otherwise.
+	defaultPlateauWindow = 20
+	// equilibriumWindow is the number of consecutive generations whose
+	// population must stay within the band.
+	equilibriumWindow = 50
+	// equilibriumTolerance is the band's width relative to the average
+	// population; it is never narrower than equilibriumMinBand cells.
+	equilibriumTolerance = 0.05
+	equilibriumMinBand   = 3
+	// growthSmoothing is the weight of the newest population change in the
+	// smoothed growth rate.
+	growthSmoothing = 0.1
+)
+
+// populationWindow is a rolling window of the most recent populations. It
+// flags a steady population size even when the pattern itself is chaotic and
+// never repeats exactly.
+type populationWindow struct {
+	counts [equilibriumWindow]int
+	size   int
+	next   int
+	// generation is the generation the newest count was taken at.
+	generation int
+	// delta is the newest count minus the one before it, and smoothedDelta
+	// an exponential moving average of it.
+	delta         int
+	smoothedDelta float64
+}
+
+func (w *populationWindow) add(generation, population int) {
+	if w.size > 0 && generation != w.generation+1 {
+		// The game was reset or its generation was set; start over.
+		w.size, w.next = 0, 0
+	}
+	if w.size > 0 {
+		w.delta = population - w.latest()
+		if w.size == 1 {
+			w.smoothedDelta = float64(w.delta)
+		} else {
+			w.smoothedDelta += (float64(w.delta) - w.smoothedDelta) * growthSmoothing
+		}
+	}
+	w.counts[w.next] = population
+	w.next = (w.next + 1) % equilibriumWindow
+	w.size = min(w.size+1, equilibriumWindow)
+	w.generation = generation
+}
+
+func (w *populationWindow) latest() int {
+	return w.counts[(w.next+equilibriumWindow-1)%equilibriumWindow]
+}
+
+// stable reports whether the window is full and its populations stay within
+// the band.
+func (w *populationWindow) stable() bool {
+	if w.size < equilibriumWindow {
+		return false
+	}
+	low, high, sum := w.counts[0], w.counts[0], 0
+	for _, count := range w.counts {
+		low, high, sum = min(low, count), max(high, count), sum+count
+	}
+	band := max(float64(sum)/equilibriumWindow*equilibriumTolerance, equilibriumMinBand)
+	return float64(high-low) <= band
+}
+
+// IsStablePopulation reports whether the populations of the last windowSize
+// generations all lie within one cell of each other, a cheap sign of a near
+// constant population. It is false until that many consecutive generations
+// have run, and for windows longer than the population history.
+func (g *Game) IsStablePopulation(windowSize int) bool {
+	w := &g.populations
+	if windowSize < 1 || windowSize > w.size {
+		return false
+	}
+	low, high := w.latest(), w.latest()
+	for lag := 1; lag <= windowSize; lag++ {
+		count := w.counts[(w.next-lag+equilibriumWindow)%equilibriumWindow]
+		low, high = min(low, count), max(high, count)
+	}
+	return high-low <= 1
+}
@@ -0,0 +1,25 @@
+package game
+
+// evolveRule flips between one and ruleEvolutionMutations randomly chosen
+// neighbor counts of the Born or Survive half of the current Life rule.
+func (g *Game) evolveRule() {
+	neighbors := 8
+	if g.gridTopology == TopologyHex {
+		neighbors = 6
+	}
+	old := g.rule
+	next := old
+	next.Name = ""
+	for range 1 + g.rng.Intn(g.ruleEvolutionMutations) {
+		count := g.rng.Intn(neighbors + 1)
+		if g.rng.Intn(2) == 0 {
+			next.Born[count], next.BornLetters[count] = !next.Born[count], ""
+		} else {
+			next.Survive[count], next.SurviveLetters[count] = !next.Survive[count], ""
+		}
+	}
+	g.setRule(next)
+	if g.onRuleChange != nil {
+		g.onRuleChange(old, next)
+	}
+}
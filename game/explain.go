@@ -0,0 +1,87 @@
+package game
+
+import "fmt"
+
+// Explain describes what happens to the cell at (x, y) in the next
+// generation and why, e.g. "alive with 1 neighbor -> dies
+// (underpopulation)", in the game's locale.
+func (g *Game) Explain(x, y int) string {
+	if x < 0 || y < 0 || x >= g.columns || y >= g.rows {
+		return fmt.Sprintf(g.t("(%d, %d) is outside the grid"), x, y)
+	}
+	switch g.mode {
+	case ModeWireWorld:
+		return g.explainWireWorld(x, y)
+	case ModeBriansBrain:
+		return g.explainBriansBrain(x, y)
+	case ModeElementary1D:
+		return fmt.Sprintf(g.t("each row follows rule W%d applied to the row above"), g.elementaryRule)
+	default:
+		return g.explainLife(x, y)
+	}
+}
+
+func (g *Game) explainLife(x, y int) string {
+	count := g.countLiveNeighbors(x, y)
+	rule := g.ruleAt(x, y)
+	next := g.nextLifeState(g.ruleTableAt(x, y), x, y)
+	if !g.currentGrid[x][y] {
+		if next {
+			return fmt.Sprintf(g.t("dead with %s -> born"), g.pluralNeighbors(count))
+		}
+		return fmt.Sprintf(g.t("dead with %s -> stays dead"), g.pluralNeighbors(count))
+	}
+	if next {
+		return fmt.Sprintf(g.t("alive with %s -> survives"), g.pluralNeighbors(count))
+	}
+	reason := g.t("dies")
+	if low, high, ok := surviveRange(rule); ok && count < low {
+		reason = g.t("dies (underpopulation)")
+	} else if ok && count > high {
+		reason = g.t("dies (overpopulation)")
+	}
+	return fmt.Sprintf(g.t("alive with %s -> %s"), g.pluralNeighbors(count), reason)
+}
+
+func (g *Game) explainWireWorld(x, y int) string {
+	switch g.states[x][y] {
+	case WireHead:
+		return g.t("electron head -> becomes a tail")
+	case WireTail:
+		return g.t("electron tail -> becomes a conductor")
+	case WireConductor:
+		count := g.countNeighborHeads(x, y)
+		heads := g.t("1 neighboring head")
+		if count != 1 {
+			heads = fmt.Sprintf(g.t("%d neighboring heads"), count)
+		}
+		if count == 1 || count == 2 {
+			return fmt.Sprintf(g.t("conductor with %s -> becomes a head"), heads)
+		}
+		return fmt.Sprintf(g.t("conductor with %s -> stays a conductor"), heads)
+	default:
+		return g.t("empty -> stays empty")
+	}
+}
+
+// surviveRange returns the smallest and largest neighbor counts a cell
+// survives with, reporting false for rules where no cell survives.
+func surviveRange(r Rule) (int, int, bool) {
+	low, high := -1, -1
+	for count, survives := range r.Survive {
+		if survives {
+			if low == -1 {
+				low = count
+			}
+			high = count
+		}
+	}
+	return low, high, low != -1
+}
+
+func (g *Game) pluralNeighbors(count int) string {
+	if count == 1 {
+		return g.t("1 neighbor")
+	}
+	return fmt.Sprintf(g.t("%d neighbors"), count)
+}
@@ -0,0 +1,39 @@
+package game
+
+// maxExtremumSteps caps how far StepToPeak and StepToTrough look for the
+// population to turn.
+const maxExtremumSteps = 10000
+
+// StepToPeak advances a Life game past the next generation whose population
+// is a local maximum, where it rose before and falls next, and pauses it on
+// the first generation that falls. It stops early once the grid repeats or
+// the population plateaus, and gives up after maxExtremumSteps generations.
+func (g *Game) StepToPeak() {
+	g.stepToExtremum(1)
+}
+
+// StepToTrough is StepToPeak for the next local minimum.
+func (g *Game) StepToTrough() {
+	g.stepToExtremum(-1)
+}
+
+// stepToExtremum steps until the population has moved in direction and then
+// against it, or stops changing.
+func (g *Game) stepToExtremum(direction int) {
+	if g.mode != ModeLife || g.playback != nil {
+		return
+	}
+	g.state = Paused
+	previous := g.Population()
+	turned := false
+	for range maxExtremumSteps {
+		g.advance()
+		population := g.populations.latest()
+		delta := population - previous
+		previous = population
+		if turned && delta*direction < 0 || g.periods.period != 0 || g.IsStablePopulation(g.plateauWindow) {
+			return
+		}
+		turned = turned || delta*direction > 0
+	}
+}
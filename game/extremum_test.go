@@ -0,0 +1,42 @@
+package game
+
+import "testing"
+
+// newExtremumGame creates a bounded Life game with the given cells alive.
+func newExtremumGame(t *testing.T, cells ...[2]int) *Game {
+	g := NewFromOptions(Options{CellSize: 10})
+	t.Cleanup(g.Close)
+	for _, cell := range cells {
+		g.currentGrid[cell[0]][cell[1]] = true
+	}
+	return g
+}
+
+func TestStepToPeakStopsOnStillLife(t *testing.T) {
+	g := newExtremumGame(t, [2]int{20, 20}, [2]int{21, 20}, [2]int{20, 21}, [2]int{21, 21})
+	g.StepToPeak()
+	if g.generation > 2 {
+		t.Errorf("StepToPeak ran %d generations of a block", g.generation)
+	}
+}
+
+func TestStepToExtremum(t *testing.T) {
+	// The T-tetromino's populations run 4, 7, 6, 6, 8, ...
+	tetromino := [][2]int{{21, 20}, {20, 21}, {21, 21}, {22, 21}}
+	for _, tt := range []struct {
+		name                   string
+		step                   func(g *Game)
+		generation, population int
+	}{
+		{"peak", (*Game).StepToPeak, 2, 6},
+		{"trough", (*Game).StepToTrough, 4, 8},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newExtremumGame(t, tetromino...)
+			tt.step(g)
+			if g.generation != tt.generation || g.Population() != tt.population {
+				t.Errorf("stopped at generation %d with %d cells, want generation %d with %d", g.generation, g.Population(), tt.generation, tt.population)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+)
+
+// CellFillStyle is the pattern drawn over live cells, so that they can be
+// told apart from dead ones by more than their color.
+type CellFillStyle int
+
+const (
+	CellFillSolid CellFillStyle = iota
+	CellFillHatch
+	CellFillDots
+)
+
+func (s CellFillStyle) String() string {
+	switch s {
+	case CellFillSolid:
+		return "Solid"
+	case CellFillHatch:
+		return "Hatch"
+	case CellFillDots:
+		return "Dots"
+	default:
+		return "Unknown"
+	}
+}
+
+// hatchSpacing is the horizontal distance in pixels between the lines of
+// CellFillHatch.
+const hatchSpacing = 4
+
+// drawFillPattern draws the pattern of style over the cell already drawn on
+// img, in a color that contrasts with c. The pattern is clipped to the cell's
+// shape by only drawing where img is opaque.
+func drawFillPattern(img *ebiten.Image, size float32, style CellFillStyle, c color.Color) {
+	if style == CellFillSolid {
+		return
+	}
+	bounds := img.Bounds()
+	overlay := ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	defer overlay.Deallocate()
+	height := float32(bounds.Dy())
+	switch style {
+	case CellFillHatch:
+		for x := -height; x < size; x += hatchSpacing {
+			vector.StrokeLine(overlay, x, height, x+height, 0, 1, contrastingColor(c), true)
+		}
+	case CellFillDots:
+		drawDeadCellPattern(overlay, 0, 0, max(size, height), contrastingColor(c))
+	}
+	img.DrawImage(overlay, &ebiten.DrawImageOptions{Blend: ebiten.BlendSourceAtop})
+}
@@ -3,18 +3,34 @@ package game
 import (
 	"fmt"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
 	"log"
+	"math/rand"
+	"runtime"
+	"time"
 )
 
 const (
 	ScreenWidth  = 640
 	ScreenHeight = 480
 	MinCellSize  = 5
-	maxColumns   = ScreenWidth / MinCellSize
-	maxRows      = ScreenHeight / MinCellSize
+	// The grid storage is sized for one pixel cells, the smallest that
+	// Options.MinCellSize allows.
+	maxColumns = ScreenWidth
+	maxRows    = ScreenHeight
+)
+
+const (
+	// maxSlowFactor is as slow as the slow-down key goes.
+	maxSlowFactor = 256
+	// maxStepsPerGeneration is as fast as the fast-forward key goes.
+	maxStepsPerGeneration = 128
+	// defaultGenerationsPerSecond is the speed unless
+	// Options.GenerationsPerSecond says otherwise.
+	defaultGenerationsPerSecond = 8
 )
 
 type State int
@@ -34,75 +50,776 @@ const (
 	Running
 )
 
+type Grid [maxColumns][maxRows]bool
+
 type Game struct {
-	grid               [maxColumns][maxRows]bool
+	// gridA and gridB are alternated between generations; currentGrid
+	// points at the one holding the current generation. The multi-state
+	// modes alternate statesA and statesB the same way through states.
+	gridA              Grid
+	gridB              Grid
+	statesA            [maxColumns][maxRows]uint8
+	statesB            [maxColumns][maxRows]uint8
+	currentGrid        *Grid
 	cellSize           int
+	minCellSize        int
+	screenWidth        int
+	screenHeight       int
+	borderCells        int
+	wrapX              bool
+	wrapY              bool
+	gridTopology       GridTopology
+	hexOutline         hexOutline
 	columns            int
 	rows               int
-	ticks              int
+	ticks              float64
 	generation         int
-	ticksPerGeneration int
+	ticksPerGeneration float64
 	state              State
-	selectedThemeID    ThemeID
-	darkTheme          *Theme
-	lightTheme         *Theme
+	mode               Mode
+	states             *[maxColumns][maxRows]uint8
+	rule               Rule
+	ruleTable          ruleTable
+	rulePreset         int
+	elementaryRule     byte
+	seed               int64
+	rng                *rand.Rand
+	rngSource          *countingSource
+	randomMode         RandomMode
+	elementarySeed     [maxColumns]bool
+	elementaryRow      int
+	entry              numericEntry
+	pastes             chan clipboardResult
+	pasteX             int
+	pasteY             int
+	workers            int
+	pool               *cyclePool
+	sound              *soundPlayer
+	patternedCells     bool
+	cellFill           CellFillStyle
+	slowMotion         bool
+	locale             *Locale
+	// renderBuffer, when set, is the fixed-resolution image the grid is
+	// drawn into before being scaled to the screen; renderScale is its size
+	// relative to the screen.
+	renderBuffer *ebiten.Image
+	renderScale  float32
+	sprites      cellSprites
+	keyBindings  KeyBindings
+	populations  populationWindow
+	// visualStepDelay is the wall-clock time to wait at least between
+	// generations; lastGenerationAt is when the last one was cycled.
+	visualStepDelay  time.Duration
+	lastGenerationAt time.Time
+	// slowFactor divides the tick rate.
+	slowFactor float64
+	// previewing shows the next generation under the next rule preset as a
+	// ghost overlay while paused, computed into previewGrid.
+	previewing  bool
+	previewGrid Grid
+	// turboMode cycles for most of every frame instead of once every
+	// ticksPerGeneration ticks.
+	turboMode      bool
+	generationRate rateMeter
+	// cellRate counts the cells cycled per second.
+	cellRate rateMeter
+	recorder *recorder
+	// renderEveryNGenerations skips redrawing the grid between every Nth
+	// generation, reusing frameCache (or renderBuffer) in the meantime.
+	renderEveryNGenerations int
+	lastRenderedGeneration  int
+	frameCache              *ebiten.Image
+	autoPersistPath         string
+	history                 *historyRecorder
+	journal                 *journal
+	// playback holds the history frames still to be replayed.
+	playback [][]byte
+	periods  periodDetector
+	// autoPause pauses the game once periods detects a repeating grid.
+	autoPause bool
+	// stepsPerGeneration is the fast-forward factor: how many generations
+	// are cycled each time ticksPerGeneration ticks have passed.
+	stepsPerGeneration     int
+	plateauWindow          int
+	ruleEvolutionInterval  int
+	ruleEvolutionMutations int
+	onRuleChange           func(oldRule, newRule Rule)
+	oversizePolicy         OversizePolicy
+	// ages counts the generations each live cell has survived for; see
+	// CellAge.
+	ages                [maxColumns][maxRows]uint16
+	showAgeHistogram    bool
+	showQuadrantStats   bool
+	identifyObjects     bool
+	histogram           []int
+	histogramGeneration int
+	// everAlive marks the cells that have been alive since the last reset.
+	everAlive [maxColumns][maxRows]bool
+	// everDead marks the cells that have been dead since the last reset.
+	everDead          [maxColumns][maxRows]bool
+	showZoneHighlight bool
+	showVirginCells   bool
+	// componentConnectivity is 4 or 8 while live cells are colored by
+	// connected component, and 0 otherwise.
+	componentConnectivity int
+	componentLabels       [maxColumns][maxRows]int32
+	components            int
+	zones                 []Zone
+	zoneTables            []ruleTable
+	// zoneOf is the 1-based index of the zone each cell is in, or 0 for the
+	// cells that follow the game's rule.
+	zoneOf             [maxColumns][maxRows]uint16
+	showZones          bool
+	pauseWhenUnfocused bool
+	// painting is set while a left-button drag paints cells paintAlive.
+	painting               bool
+	paintAlive             bool
+	lastPaintX, lastPaintY int
+	// rectangling is set while a Shift-drag outlines a rectangle to fill.
+	rectangling                        bool
+	rectangleAnchorX, rectangleAnchorY int
+	rectangleX, rectangleY             int
+	// undoStack holds the snapshots Undo restores. lastEditTime is when the
+	// edit that is still being coalesced into the newest one was made.
+	undoStack    []undoSnapshot
+	lastEditTime time.Time
+	// panDragging is set while a middle-button drag pans the view from the
+	// cursor position panDragX, panDragY of the previous frame.
+	panDragging           bool
+	panDragX, panDragY    int
+	showGenerationCounter bool
+	renderMode            RenderMode
+	// renderEnabled is cleared by SkipRendering; renderSkipFrames counts
+	// down the frames until it is set again, if it is not zero.
+	renderEnabled    bool
+	renderSkipFrames int
+	// invertedTheme, when set, is the inverted copy of the selected theme
+	// that is drawn instead of it.
+	invertedTheme *Theme
+	tooltip       *ebiten.Image
+	// textBuffer is the image drawText prints into, kept while it is wide
+	// enough.
+	textBuffer    *ebiten.Image
+	hudFace       *text.GoTextFace
+	hudPosition   HUDPosition
+	lockedActions map[Action]bool
+	// showScenarios lists the scenarios, read into scenarios, to pick one
+	// from with the number keys.
+	showScenarios bool
+	scenarios     []*Scenario
+	// cameraLocked keeps the view centered on the live cells while running.
+	cameraLocked bool
+	// followingCentroid eases the view toward the centroid every frame.
+	followingCentroid bool
+	// showCentroid draws the centroid and shows its velocity, tracked each
+	// generation while it is set.
+	showCentroid        bool
+	ageWeightedCentroid bool
+	centroid            centroidTracker
+	// sessionMaxPop, sessionExtinctions and sessionGeneration are kept
+	// across resets until NewSession; sessionSummary describes them as of
+	// the last reset.
+	sessionMaxPop      int
+	sessionExtinctions int
+	sessionGeneration  int
+	sessionSummary     string
+	// zoom scales the view, so that cells are shown at zoom times their
+	// size.
+	zoom float64
+	// offsetX and offsetY pan the view, in screen pixels.
+	offsetX float64
+	offsetY float64
+	// baseTicksPerGeneration is the speed slow-motion mode returns to.
+	baseTicksPerGeneration float64
+	lastPopulation         int
+	// lastPopulationGeneration is the generation lastPopulation was
+	// counted at.
+	lastPopulationGeneration int
+	selectedThemeID          ThemeID
+	themes                   []*Theme
 }
 
 type Options struct {
-	CellSize int
+	CellSize       int
+	Mode           Mode
+	ElementaryRule byte
+	// MinCellSize is the smallest cell size CellSize, SetCellSize and
+	// zooming allow, at least 1 for one pixel cells. Zero uses the
+	// MinCellSize constant.
+	MinCellSize int
+	// BorderCells reserves a dimmed margin of this many cells around the
+	// play area. Cells live and die there as usual.
+	BorderCells int
+	Topology    Topology
+	// WrapX and WrapY wrap a single axis of a bounded grid, e.g. WrapX
+	// alone makes a horizontal cylinder.
+	WrapX bool
+	WrapY bool
+	// GridTopology selects square or hexagonal cells. Hex grids only
+	// support Life mode.
+	GridTopology GridTopology
+	// Rule overrides the starting Life rule in B/S notation, e.g. "B36/S23".
+	// Hex grids count at most 6 neighbors.
+	Rule string
+	// EnableSound plays a sound per generation for the cells born and died.
+	EnableSound bool
+	// PatternedCells dots dead cells so that they can be told apart from
+	// live ones without relying on color.
+	PatternedCells bool
+	// CellFillStyle draws a pattern over live cells, for the same reason.
+	CellFillStyle CellFillStyle
+	// SlowMotion temporarily slows the game down when the population
+	// changes sharply, e.g. when patterns collide.
+	SlowMotion bool
+	// LocalePath points at a JSON file of HUD translations, see Locale.
+	// HUD text is in English when it is empty.
+	LocalePath string
+	// RenderWidth and RenderHeight, when set, draw the grid into an
+	// offscreen image of that size which is then scaled to the window, so
+	// that RenderBuffer can be captured at a resolution other than the
+	// window's.
+	RenderWidth  int
+	RenderHeight int
+	// KeyBindings overrides the keys of individual actions, modifiers
+	// included; actions it leaves out keep their DefaultKeyBindings key.
+	KeyBindings KeyBindings
+	// LockedActions are ignored, e.g. to keep visitors from resetting a
+	// kiosk. Locking ActionEdit ignores clicks and drags on the grid.
+	LockedActions []Action
+	// VisualStepDelay, when set, holds each generation on screen for at
+	// least this long regardless of the tick rate.
+	VisualStepDelay time.Duration
+	// GenerationsPerSecond is the speed of a running game, which may be
+	// fractional, e.g. 1.5. Zero uses a default of 8.
+	GenerationsPerSecond float64
+	// SlowFactor slows the game down by counting only one tick in every
+	// SlowFactor frames. It must be at least 1; zero means 1.
+	SlowFactor float64
+	// RenderEveryNGenerations, when greater than 1, only redraws the grid
+	// every N generations while running, for when drawing is the
+	// bottleneck. The HUD is still drawn every frame.
+	RenderEveryNGenerations int
+	// AutoPersistPath, when set, is where the game saves its state when the
+	// window is closed and restores it from on the next start.
+	AutoPersistPath string
+	// DetectPeriodUpTo is the longest period, in generations, the still life
+	// and oscillator detector looks for. Longer periods cost memory and a
+	// little time per generation. Zero uses a default of 15.
+	DetectPeriodUpTo int
+	// AutoPause pauses the game when the detector finds a still life or an
+	// oscillator, rather than only reporting it.
+	AutoPause bool
+	// PlateauWindow is the number of generations whose populations must stay
+	// within one cell of each other for the HUD to show a plateau. Zero uses
+	// a default of 20; it can be at most 50.
+	PlateauWindow int
+	// PauseWhenUnfocused stops the simulation while the window does not
+	// have focus.
+	PauseWhenUnfocused bool
+	// OnOversizePattern decides what pattern loaders do with patterns that
+	// are larger than the grid.
+	OnOversizePattern OversizePolicy
+	// RuleEvolutionInterval, when set, mutates the Life rule every this many
+	// generations by adding or removing up to RuleEvolutionMutations
+	// neighbor counts, which defaults to 1.
+	RuleEvolutionInterval  int
+	RuleEvolutionMutations int
+	// OnRuleChange is called after each rule evolution.
+	OnRuleChange func(oldRule, newRule Rule)
+	// HUDFontSize is the size of the HUD text in pixels; zero uses a default
+	// of 12. HUDPosition is the corner it is drawn in.
+	HUDFontSize int
+	HUDPosition HUDPosition
+	// AgeWeightedCentroid weights each live cell by its age in LiveCentroid.
+	AgeWeightedCentroid bool
+	// RandomMode picks how the randomize key fills the grid.
+	RandomMode RandomMode
+	// IdentifyObjects names the object on the grid in the HUD, when it is
+	// one IdentifyObject recognizes.
+	IdentifyObjects bool
+	// Seed seeds Randomize. Zero picks a time-based seed.
+	Seed int64
 }
 
 func NewFromOptions(options Options) *Game {
-	if options.CellSize < MinCellSize {
-		log.Fatalf("cell size must be greater than or equal to %d, got %d", MinCellSize, options.CellSize)
+	g, err := newFromOptions(options)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return g
+}
+
+// newFromOptions creates a game like NewFromOptions, returning an error
+// instead of exiting when the options are invalid.
+func newFromOptions(options Options) (*Game, error) {
+	if options.MinCellSize < 0 {
+		return nil, fmt.Errorf("min cell size must be at least 1, got %d", options.MinCellSize)
+	}
+	if options.MinCellSize == 0 {
+		options.MinCellSize = MinCellSize
+	}
+	if options.CellSize < options.MinCellSize {
+		return nil, fmt.Errorf("cell size must be greater than or equal to %d, got %d", options.MinCellSize, options.CellSize)
+	}
+	if options.CellSize > min(ScreenWidth, ScreenHeight) {
+		return nil, fmt.Errorf("cell size must be at most %d for the grid to have a row and a column, got %d", min(ScreenWidth, ScreenHeight), options.CellSize)
 	}
 	columns := ScreenWidth / options.CellSize
 	rows := ScreenHeight / options.CellSize
-	darkTheme, lightTheme := NewDarkTheme(), NewLightTheme()
-	return &Game{
-		cellSize:           options.CellSize,
-		columns:            columns,
-		rows:               rows,
-		state:              Paused,
-		ticksPerGeneration: ebiten.TPS() / 8,
-		darkTheme:          darkTheme,
-		lightTheme:         lightTheme,
-		selectedThemeID:    darkTheme.ID,
+	if options.BorderCells < 0 || 2*options.BorderCells >= min(columns, rows) {
+		return nil, fmt.Errorf("border cells must be between 0 and %d, got %d", (min(columns, rows)-1)/2, options.BorderCells)
+	}
+	if options.GridTopology == TopologyHex && options.Mode != ModeLife {
+		return nil, fmt.Errorf("hex grids only support %s mode, got %s", ModeLife, options.Mode)
+	}
+	rule := Conway
+	if options.GridTopology == TopologyHex {
+		rule = HexRule
+	}
+	if options.Rule != "" {
+		var err error
+		if rule, err = ParseRule(options.Rule); err != nil {
+			return nil, fmt.Errorf("invalid rule: %w", err)
+		}
+		if err := options.GridTopology.checkRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid rule: %w", err)
+		}
+	}
+	seed := options.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	source := newCountingSource(seed)
+	g := &Game{
+		cellSize:                options.CellSize,
+		minCellSize:             options.MinCellSize,
+		screenWidth:             ScreenWidth,
+		screenHeight:            ScreenHeight,
+		borderCells:             options.BorderCells,
+		wrapX:                   options.Topology == TopologyToroidal || options.WrapX,
+		wrapY:                   options.Topology == TopologyToroidal || options.WrapY,
+		gridTopology:            options.GridTopology,
+		patternedCells:          options.PatternedCells,
+		cellFill:                options.CellFillStyle,
+		slowMotion:              options.SlowMotion,
+		visualStepDelay:         options.VisualStepDelay,
+		renderEveryNGenerations: options.RenderEveryNGenerations,
+		lastRenderedGeneration:  -1,
+		autoPersistPath:         options.AutoPersistPath,
+		autoPause:               options.AutoPause,
+		stepsPerGeneration:      1,
+		zoom:                    1,
+		renderMode:              RenderDebug,
+		renderEnabled:           true,
+		oversizePolicy:          options.OnOversizePattern,
+		columns:                 columns,
+		rows:                    rows,
+		state:                   Paused,
+		elementaryRule:          options.ElementaryRule,
+		seed:                    seed,
+		rng:                     rand.New(source),
+		rngSource:               source,
+		themes:                  []*Theme{NewDarkTheme(), NewLightTheme(), NewWireWorldTheme(), NewSolarizedTheme(), NewHighContrastTheme()},
+		selectedThemeID:         Dark,
+		pastes:                  make(chan clipboardResult, 1),
+	}
+	if options.LocalePath != "" {
+		locale, err := LoadLocale(options.LocalePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load locale: %w", err)
+		}
+		g.locale = locale
+	}
+	if options.RenderWidth < 0 || options.RenderHeight < 0 || (options.RenderWidth == 0) != (options.RenderHeight == 0) {
+		return nil, fmt.Errorf("render width and height must both be positive or both be zero, got %dx%d", options.RenderWidth, options.RenderHeight)
+	}
+	if options.SlowFactor != 0 && options.SlowFactor < 1 {
+		return nil, fmt.Errorf("slow factor must be at least 1, got %g", options.SlowFactor)
+	}
+	g.slowFactor = max(options.SlowFactor, 1)
+	if options.GenerationsPerSecond < 0 {
+		return nil, fmt.Errorf("generations per second must be non-negative, got %g", options.GenerationsPerSecond)
+	}
+	if options.GenerationsPerSecond == 0 {
+		options.GenerationsPerSecond = defaultGenerationsPerSecond
+	}
+	g.ticksPerGeneration = float64(ebiten.TPS()) / options.GenerationsPerSecond
+	if options.DetectPeriodUpTo < 0 {
+		return nil, fmt.Errorf("detect period up to must be non-negative, got %d", options.DetectPeriodUpTo)
+	}
+	if options.DetectPeriodUpTo == 0 {
+		options.DetectPeriodUpTo = defaultDetectPeriodUpTo
+	}
+	g.periods = newPeriodDetector(options.DetectPeriodUpTo)
+	if options.PlateauWindow < 0 || options.PlateauWindow > equilibriumWindow {
+		return nil, fmt.Errorf("plateau window must be between 0 and %d, got %d", equilibriumWindow, options.PlateauWindow)
+	}
+	g.plateauWindow = options.PlateauWindow
+	if g.plateauWindow == 0 {
+		g.plateauWindow = defaultPlateauWindow
+	}
+	if options.RuleEvolutionInterval < 0 || options.RuleEvolutionMutations < 0 {
+		return nil, fmt.Errorf("rule evolution interval and mutations must be non-negative, got %d and %d", options.RuleEvolutionInterval, options.RuleEvolutionMutations)
+	}
+	g.ruleEvolutionInterval = options.RuleEvolutionInterval
+	g.ruleEvolutionMutations = max(options.RuleEvolutionMutations, 1)
+	g.onRuleChange = options.OnRuleChange
+	g.pauseWhenUnfocused = options.PauseWhenUnfocused
+	g.ageWeightedCentroid = options.AgeWeightedCentroid
+	g.randomMode = options.RandomMode
+	g.identifyObjects = options.IdentifyObjects
+	if options.HUDFontSize < 0 {
+		return nil, fmt.Errorf("HUD font size must be non-negative, got %d", options.HUDFontSize)
 	}
+	if options.HUDFontSize == 0 {
+		options.HUDFontSize = defaultHUDFontSize
+	}
+	g.hudFace = hudFace(options.HUDFontSize)
+	g.hudPosition = options.HUDPosition
+	g.keyBindings = DefaultKeyBindings()
+	for action, key := range options.KeyBindings {
+		g.keyBindings[action] = key
+	}
+	g.lockedActions = map[Action]bool{}
+	for _, action := range options.LockedActions {
+		g.lockedActions[action] = true
+	}
+	g.renderScale = 1
+	if options.RenderWidth > 0 {
+		g.renderBuffer = ebiten.NewImage(options.RenderWidth, options.RenderHeight)
+		g.updateRenderScale()
+	}
+	g.baseTicksPerGeneration = g.ticksPerGeneration
+	g.currentGrid = &g.gridA
+	g.states = &g.statesA
+	g.workers = runtime.NumCPU()
+	g.pool = newCyclePool(g.workers, g.cycleLifeBand)
+	if options.EnableSound {
+		sound, err := newSoundPlayer()
+		if err != nil {
+			log.Printf("sound disabled: %v", err)
+		}
+		g.sound = sound
+	}
+	g.setRule(rule)
+	g.SetMode(options.Mode)
+	if g.autoPersistPath != "" {
+		g.loadAutoPersisted()
+		ebiten.SetWindowClosingHandled(true)
+	}
+	return g, nil
 }
 
 func (g *Game) Update() error {
+	if g.autoPersistPath != "" && ebiten.IsWindowBeingClosed() {
+		if err := g.saveAutoPersisted(); err != nil {
+			log.Printf("failed to save the session: %v", err)
+		}
+		return ebiten.Termination
+	}
+	if g.entry.active {
+		g.entry.update()
+		return nil
+	}
+	if g.pauseWhenUnfocused && !ebiten.IsFocused() {
+		return nil
+	}
 	if g.state == Running {
-		g.ticks++
+		g.ticks += 1 / g.slowFactor
 	}
-	if g.ticks == g.ticksPerGeneration {
-		g.ticks = 0
-		g.cycle()
+	now := time.Now()
+	g.generationRate.update(now)
+	g.cellRate.update(now)
+	if g.turboActive() {
+		g.runTurbo()
+	} else if g.ticks >= g.ticksPerGeneration && time.Since(g.lastGenerationAt) >= g.visualStepDelay {
+		// Rates above one generation per tick cycle several; the fraction
+		// of a generation left over carries on to the next frames.
+		generations := int(g.ticks / g.ticksPerGeneration)
+		g.ticks -= float64(generations) * g.ticksPerGeneration
+		if g.visualStepDelay > 0 {
+			generations = 1
+		}
+		for range generations * g.stepsPerGeneration {
+			g.advance()
+			if g.state == Paused {
+				break
+			}
+		}
+		g.lastGenerationAt = time.Now()
 	}
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+	switch {
+	case g.lockedActions[ActionEdit]:
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
 		if g.state == Running {
 			g.state = Paused
 		}
-		x, y := ebiten.CursorPosition()
-		cellX, cellY := x/g.cellSize, y/g.cellSize
-		g.grid[cellX][cellY] = !g.grid[cellX][cellY]
+		if cellX, cellY, ok := g.cellAt(ebiten.CursorPosition()); ok && !g.startRectangle(cellX, cellY) {
+			g.toggleCell(cellX, cellY)
+			g.startPainting(cellX, cellY)
+		}
+	default:
+		g.paintDrag()
+		g.dragRectangle()
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+	if g.actionJustPressed(ActionTogglePlay) {
 		g.toggleState()
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
-		g.reset()
+	if g.actionJustPressed(ActionReset) {
+		g.hardReset()
+	}
+	if g.actionJustPressed(ActionSoftReset) {
+		g.SoftReset()
 	}
-	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+	if g.actionJustPressed(ActionSwitchTheme) {
 		g.switchTheme()
 	}
+	if g.actionJustPressed(ActionSwitchRule) && g.mode == ModeLife && g.gridTopology == TopologySquare {
+		g.cycleRule()
+	}
+	if g.actionJustPressed(ActionSetGeneration) {
+		g.entry.open("Set generation", g.SetGeneration)
+	}
+	if g.actionJustPressed(ActionStep) && g.state == Paused {
+		g.advance()
+	}
+	if g.actionJustPressed(ActionStepToPeak) {
+		g.StepToPeak()
+	}
+	if g.actionJustPressed(ActionStepToTrough) {
+		g.StepToTrough()
+	}
+	if g.actionJustPressed(ActionSlowDown) {
+		g.slowFactor = min(g.slowFactor*2, maxSlowFactor)
+	}
+	if g.actionJustPressed(ActionSpeedUp) {
+		g.slowFactor = max(g.slowFactor/2, 1)
+	}
+	if g.actionJustPressed(ActionPreviewRule) {
+		g.previewing = !g.previewing
+	}
+	if g.actionJustPressed(ActionFastForward) {
+		g.stepsPerGeneration = min(g.stepsPerGeneration*2, maxStepsPerGeneration)
+	}
+	if g.actionJustPressed(ActionSlowForward) {
+		g.stepsPerGeneration = max(g.stepsPerGeneration/2, 1)
+	}
+	if g.actionJustPressed(ActionToggleAgeHistogram) {
+		g.showAgeHistogram = !g.showAgeHistogram
+	}
+	if g.actionJustPressed(ActionToggleQuadrantStats) {
+		g.showQuadrantStats = !g.showQuadrantStats
+	}
+	if g.actionJustPressed(ActionToggleCentroid) {
+		g.showCentroid = !g.showCentroid
+		g.centroid = centroidTracker{}
+		if g.showCentroid || g.followingCentroid {
+			g.trackCentroid()
+		}
+	}
+	if g.actionJustPressed(ActionToggleTurbo) {
+		g.turboMode = !g.turboMode
+	}
+	if g.actionJustPressed(ActionToggleAutoPause) {
+		g.autoPause = !g.autoPause
+	}
+	if g.actionJustPressed(ActionRandomize) {
+		if g.randomMode == RandomBiased && g.mode == ModeLife {
+			g.RandomInteresting(g.rng.Int63())
+		} else {
+			g.Randomize(SymmetryNone)
+		}
+	}
+	if g.actionJustPressed(ActionToggleRendering) {
+		if g.renderEnabled {
+			g.SkipRendering(0)
+		} else {
+			g.renderEnabled = true
+		}
+	}
+	if g.actionJustPressed(ActionListScenarios) {
+		g.showScenarios = !g.showScenarios
+	}
+	if g.showScenarios {
+		g.selectScenario()
+	}
+	if g.actionJustPressed(ActionUndo) {
+		g.Undo()
+	}
+	if g.actionJustPressed(ActionCopy) {
+		if err := g.CopyToClipboard(); err != nil {
+			log.Printf("could not copy the grid: %v", err)
+		}
+	}
+	if g.actionJustPressed(ActionPaste) {
+		if cellX, cellY, ok := g.cellAt(ebiten.CursorPosition()); ok {
+			g.requestPaste(cellX, cellY)
+		}
+	}
+	if g.actionJustPressed(ActionToggleVirginCells) {
+		g.showVirginCells = !g.showVirginCells
+	}
+	if g.actionJustPressed(ActionColorComponents) {
+		g.cycleComponentColoring()
+	}
+	if g.actionJustPressed(ActionToggleZones) {
+		g.showZones = !g.showZones
+	}
+	if g.actionJustPressed(ActionInvertTheme) {
+		g.toggleInvertedTheme()
+	}
+	if g.actionJustPressed(ActionCycleRenderMode) {
+		g.renderMode = (g.renderMode + 1) % (RenderDebug + 1)
+	}
+	if g.actionJustPressed(ActionToggleBigCounter) {
+		g.showGenerationCounter = !g.showGenerationCounter
+	}
+	if g.actionJustPressed(ActionLockCamera) {
+		g.cameraLocked = !g.cameraLocked
+		if g.cameraLocked {
+			g.centerOnLiveCells()
+		}
+	}
+	if g.actionJustPressed(ActionFollowCentroid) {
+		g.followingCentroid = !g.followingCentroid
+		if g.followingCentroid {
+			g.trackCentroid()
+		}
+	}
+	if g.followingCentroid {
+		g.followCentroid()
+	}
+	if g.state == Paused {
+		g.panKeys()
+	}
+	g.panDrag()
+	g.zoomWheel()
+	if g.actionJustPressed(ActionToggleZoneHighlight) {
+		g.showZoneHighlight = !g.showZoneHighlight
+	}
+	if g.actionJustPressed(ActionTogglePatternedCells) {
+		g.patternedCells = !g.patternedCells
+	}
+	if g.sound != nil {
+		if g.actionJustPressed(ActionVolumeUp) {
+			g.sound.changeVolume(soundVolumeStep)
+		}
+		if g.actionJustPressed(ActionVolumeDown) {
+			g.sound.changeVolume(-soundVolumeStep)
+		}
+	}
+	g.applyPendingPaste()
+	if g.journal != nil {
+		g.journalChanges(journalEdit)
+	}
 	return nil
 }
 
+// cellAt maps a screen position to the cell under it.
+func (g *Game) cellAt(x, y int) (int, int, bool) {
+	x, y = g.worldPosition(x, y)
+	if x < 0 || y < 0 {
+		return 0, 0, false
+	}
+	if g.gridTopology == TopologyHex {
+		return g.hexCellAt(x, y)
+	}
+	cellX, cellY := x/g.cellSize, y/g.cellSize
+	return cellX, cellY, cellX < g.columns && cellY < g.rows
+}
+
+func (g *Game) toggleCell(cellX, cellY int) {
+	g.recordEdit()
+	switch g.mode {
+	case ModeWireWorld, ModeBriansBrain:
+		g.states[cellX][cellY] = (g.states[cellX][cellY] + 1) % g.stateCount()
+	case ModeElementary1D:
+		if cellY == 0 {
+			g.toggleElementarySeed(cellX)
+		}
+	default:
+		g.currentGrid[cellX][cellY] = !g.currentGrid[cellX][cellY]
+		g.ages[cellX][cellY] = 0
+		g.everAlive[cellX][cellY] = true
+		g.everDead[cellX][cellY] = true
+	}
+}
+
 func (g *Game) Draw(screen *ebiten.Image) {
+	if !g.renderEnabled {
+		g.drawSkippedFrame(screen)
+		return
+	}
+	target := g.renderBuffer
+	if target == nil && (g.renderEveryNGenerations > 1 || g.transformed()) {
+		if g.frameCache == nil || g.frameCache.Bounds() != screen.Bounds() {
+			g.frameCache = ebiten.NewImage(screen.Bounds().Dx(), screen.Bounds().Dy())
+			g.lastRenderedGeneration = -1
+		}
+		target = g.frameCache
+	}
+	if target == nil {
+		g.drawScene(screen)
+	} else {
+		if g.sceneDue() {
+			g.drawScene(target)
+			g.lastRenderedGeneration = g.generation
+		}
+		scale := 1.0
+		if target == g.renderBuffer {
+			scale = float64(g.renderScale)
+		}
+		g.drawView(screen, target, scale)
+	}
+	if g.renderMode != RenderMinimal {
+		if g.renderMode == RenderDebug {
+			g.drawDebugInfo(screen)
+		}
+		g.drawTooltip(screen)
+		g.drawAgeHistogram(screen)
+		g.drawGenerationCounter(screen)
+	}
+	g.drawStatusBar(screen)
+	if g.recorder != nil {
+		g.capture(screen)
+	}
+}
+
+// SkipRendering stops drawing the grid, which leaves more of each frame to
+// the simulation, for the given number of frames or, when frames is zero,
+// until ActionToggleRendering is pressed. The HUD is still drawn.
+func (g *Game) SkipRendering(frames int) {
+	g.renderEnabled = false
+	g.renderSkipFrames = max(frames, 0)
+}
+
+func (g *Game) drawSkippedFrame(screen *ebiten.Image) {
 	g.drawBackground(screen)
-	g.drawGrid(screen)
 	g.drawDebugInfo(screen)
+	g.drawStatusBar(screen)
+	if g.renderSkipFrames > 0 {
+		g.renderSkipFrames--
+		g.renderEnabled = g.renderSkipFrames == 0
+	}
+}
+
+// sceneDue reports whether the cached scene is out of date. While running
+// with RenderEveryNGenerations set it is only redrawn every N generations;
+// while paused it is redrawn every frame so that edits show up.
+func (g *Game) sceneDue() bool {
+	return g.renderEveryNGenerations <= 1 || g.state == Paused || g.lastRenderedGeneration < 0 ||
+		g.generation < g.lastRenderedGeneration || g.generation-g.lastRenderedGeneration >= g.renderEveryNGenerations
+}
+
+func (g *Game) drawScene(dst *ebiten.Image) {
+	if g.renderMode == RenderMinimal {
+		g.drawLiveCells(dst)
+		return
+	}
+	g.drawBackground(dst)
+	g.drawBorder(dst)
+	g.drawGrid(dst)
+	g.drawPreview(dst)
 }
 
 func (g *Game) drawDebugInfo(screen *ebiten.Image) {
@@ -110,59 +827,367 @@ func (g *Game) drawDebugInfo(screen *ebiten.Image) {
 	tps := ebiten.ActualTPS()
 	maxTps := ebiten.TPS()
 	msg := fmt.Sprintf(
-		"FPS: %.2f\nTPS: %.2f (%d)\nTPG: %d\nGeneration: %d\nGame State: %s\nTheme: %s\nPress R to restart\nPress Space to pause\nPress T to switch themes",
-		fps, tps, maxTps, g.ticksPerGeneration, g.generation, g.state, g.theme())
-	ebitenutil.DebugPrintAt(screen, msg, 16, 16)
+		"%s: %.2f\n%s: %.2f (%d)\n%s: %.1f\n%s: %s\n%s: %.1f\n%s: %d\n%s: %s\n%s: %s\n%s: %s\n%s: %s\n%s: %s",
+		g.t("FPS"), fps, g.t("TPS"), tps, maxTps, g.t("Generations/s"), g.generationRate.rate, g.t("CPS"), siPrefixed(g.cellRate.rate), g.t("TPG"), g.ticksPerGeneration, g.t("Generation"), g.generation,
+		g.t("Game State"), g.t(g.state.String()), g.t("Mode"), g.mode, g.t("Rule"), g.ruleName(),
+		g.t("Topology"), g.topologyName(), g.t("Theme"), g.theme())
+	if g.previewing && g.mode == ModeLife {
+		msg += fmt.Sprintf("\n%s: %s", g.t("Previewing"), g.previewedRule())
+	}
+	if description := g.periodDescription(); description != "" {
+		msg += fmt.Sprintf("\n%s: %s", g.t("Detected"), description)
+	}
+	if g.autoPause {
+		msg += "\n" + g.t("Auto-pause on detection")
+	}
+	if g.turboActive() {
+		msg += "\n" + g.t("Turbo")
+	}
+	if !g.renderEnabled {
+		msg += "\n" + g.t("Grid hidden")
+	}
+	if g.stepsPerGeneration > 1 {
+		msg += fmt.Sprintf("\n%s: %dx", g.t("Fast forward"), g.stepsPerGeneration)
+	}
+	if g.slowFactor > 1 {
+		msg += fmt.Sprintf("\n%s: %gx", g.t("Slow motion"), g.slowFactor)
+	}
+	if g.populations.size > 0 && g.populations.generation == g.generation {
+		msg += fmt.Sprintf("\n%s: %d", g.t("Population"), g.populations.latest())
+		if g.IsStablePopulation(g.plateauWindow) {
+			msg += " (" + g.t("plateau") + ")"
+		} else if g.populations.stable() {
+			msg += " (" + g.t("population stable") + ")"
+		}
+		if g.populations.size > 1 {
+			msg += fmt.Sprintf("\n%s: %+d (%s %+.1f)", g.t("Growth/gen"), g.populations.delta, g.t("average"), g.populations.smoothedDelta)
+		}
+	}
+	if g.componentConnectivity != 0 && g.mode == ModeLife && g.gridTopology == TopologySquare {
+		msg += fmt.Sprintf("\n%s: %d (%d-%s)", g.t("Components"), g.components, g.componentConnectivity, g.t("connected"))
+	}
+	if g.identifyObjects && g.mode == ModeLife && g.gridTopology == TopologySquare {
+		msg += fmt.Sprintf("\n%s: %s", g.t("Object"), g.t(g.IdentifyObject()))
+	}
+	if g.showQuadrantStats {
+		q := g.QuadrantPopulations()
+		msg += fmt.Sprintf("\nQ: TL=%d TR=%d BL=%d BR=%d", q[0], q[1], q[2], q[3])
+	}
+	if g.showCentroid {
+		msg += fmt.Sprintf("\n%s: %s", g.t("Centroid"), g.centroidDescription())
+	}
+	if g.sessionSummary != "" {
+		msg += "\n" + g.sessionSummary
+	}
+	for _, help := range []struct {
+		text   string
+		action Action
+	}{
+		{"Press %s to restart", ActionReset},
+		{"Press %s to restart from generation 0 keeping the cells", ActionSoftReset},
+		{"Press %s to pause", ActionTogglePlay},
+		{"Press %s to switch themes", ActionSwitchTheme},
+		{"Press %s to switch rules", ActionSwitchRule},
+		{"Press %s to set the generation", ActionSetGeneration},
+		{"Press %s to step while paused", ActionStep},
+		{"Press %s to step to the next population peak", ActionStepToPeak},
+		{"Press %s to step to the next population trough", ActionStepToTrough},
+		{"Press %s to randomize", ActionRandomize},
+		{"Press %s to preview the next rule", ActionPreviewRule},
+		{"Press %s to fast-forward more", ActionFastForward},
+		{"Press %s to fast-forward less", ActionSlowForward},
+		{"Press %s to toggle the cell age histogram", ActionToggleAgeHistogram},
+		{"Press %s to toggle quadrant populations", ActionToggleQuadrantStats},
+		{"Press %s to track the centroid of the live cells", ActionToggleCentroid},
+		{"Press %s to toggle turbo mode", ActionToggleTurbo},
+		{"Press %s to toggle auto-pause", ActionToggleAutoPause},
+		{"Press %s to slow down", ActionSlowDown},
+		{"Press %s to speed up", ActionSpeedUp},
+		{"Press %s to undo the last edit", ActionUndo},
+		{"Press %s to list the scenarios", ActionListScenarios},
+		{"Press %s to copy the live cells as RLE", ActionCopy},
+		{"Press %s to paste RLE or Plaintext at the cursor", ActionPaste},
+		{"Press %s to toggle patterned cells", ActionTogglePatternedCells},
+		{"Press %s to highlight cells that were never alive", ActionToggleVirginCells},
+		{"Press %s to highlight cells that never changed", ActionToggleZoneHighlight},
+		{"Press %s to color connected components", ActionColorComponents},
+		{"Press %s to show rule zones", ActionToggleZones},
+		{"Press %s to lock the view to the live cells", ActionLockCamera},
+		{"Press %s to follow the centroid of the live cells", ActionFollowCentroid},
+		{"Press %s to toggle the large generation counter", ActionToggleBigCounter},
+		{"Press %s to switch render modes", ActionCycleRenderMode},
+		{"Press %s to hide the grid while the simulation runs", ActionToggleRendering},
+		{"Press %s to invert the theme colors", ActionInvertTheme},
+	} {
+		if !g.lockedActions[help.action] {
+			msg += "\n" + fmt.Sprintf(g.t(help.text), g.keyName(help.action))
+		}
+	}
+	if !g.lockedActions[ActionEdit] {
+		msg += "\n" + g.t("Shift-drag to fill or clear a rectangle")
+	}
+	msg += "\n" + fmt.Sprintf(g.t("Press %s/%s/%s/%s to pan while paused"), g.keyName(ActionPanUp), g.keyName(ActionPanLeft), g.keyName(ActionPanDown), g.keyName(ActionPanRight))
+	if cellX, cellY, ok := g.cellAt(ebiten.CursorPosition()); ok {
+		msg += fmt.Sprintf("\n%s: (%d, %d)", g.t("Cursor"), cellX, cellY)
+		msg += fmt.Sprintf("\n%s: %s", g.t("Cell"), g.Explain(cellX, cellY))
+	}
+	if g.sound != nil {
+		msg += fmt.Sprintf("\n%s: %.0f%%\n", g.t("Sound"), g.sound.volume*100)
+		msg += fmt.Sprintf(g.t("Press %s/%s to change the volume"), g.keyName(ActionVolumeUp), g.keyName(ActionVolumeDown))
+	}
+	if g.showScenarios {
+		msg += g.scenarioList()
+	}
+	if g.entry.active {
+		msg += "\n\n" + g.entry.String()
+	}
+	g.drawHUDText(screen, msg)
+}
+
+// gridLinesHidden reports whether the cells are one pixel, which grid lines
+// would cover.
+func (g *Game) gridLinesHidden() bool {
+	return g.cellSize == 1
 }
 
 func (g *Game) drawGrid(screen *ebiten.Image) {
 	theme := g.theme()
-	for i := 0; i < g.columns; i++ {
-		x := float32(g.cellSize * i)
-		vector.StrokeLine(screen, x, 0, x, ScreenHeight, 1.0, theme.GridColor, true)
+	if theme.DeadCellColor != nil {
+		g.drawDeadCells(screen, theme.DeadCellColor)
+	}
+	g.drawVirginCells(screen)
+	if g.gridTopology == TopologyHex {
+		g.drawHexGrid(screen)
+		g.drawZoneHighlight(screen)
+		return
+	}
+	coloringComponents := g.componentConnectivity != 0 && g.mode == ModeLife
+	if coloringComponents {
+		g.components = g.labelComponents()
 	}
-	for j := 0; j < g.rows; j++ {
-		y := float32(g.cellSize * j)
-		vector.StrokeLine(screen, 0, y, ScreenWidth, y, 1.0, theme.GridColor, true)
+	size := g.drawCellSize()
+	for i := 0; i < g.columns && !g.gridLinesHidden(); i++ {
+		x := float32(i) * size
+		vector.StrokeLine(screen, x, 0, x, float32(g.screenHeight)*g.renderScale, 1.0, theme.GridColor, true)
+	}
+	for j := 0; j < g.rows && !g.gridLinesHidden(); j++ {
+		y := float32(j) * size
+		vector.StrokeLine(screen, 0, y, float32(g.screenWidth)*g.renderScale, y, 1.0, theme.GridColor, true)
 	}
 	for i := 0; i < g.columns; i++ {
 		for j := 0; j < g.rows; j++ {
-			isAlive := g.grid[i][j]
-			x, y := float32(i*g.cellSize), float32(j*g.cellSize)
-			size := float32(g.cellSize)
-			if isAlive {
-				vector.DrawFilledRect(screen, x, y, size, size, theme.CellColor, true)
+			x, y := float32(i)*size, float32(j)*size
+			if g.multiState() {
+				if state := g.states[i][j]; state != 0 {
+					g.drawSprite(screen, x, y, spriteKey{shape: theme.CellShape, color: g.stateColor(theme, state), fill: g.cellFill})
+				} else if g.patternedCells {
+					drawDeadCellPattern(screen, x, y, size, theme.GridColor)
+				}
+			} else if g.currentGrid[i][j] {
+				cellColor := theme.CellColor
+				if coloringComponents {
+					cellColor = componentColor(g.componentLabels[i][j])
+				}
+				g.drawSprite(screen, x, y, spriteKey{shape: theme.CellShape, color: cellColor, fill: g.cellFill})
+			} else if g.patternedCells {
+				drawDeadCellPattern(screen, x, y, size, theme.GridColor)
 			}
 		}
 	}
+	g.drawZoneHighlight(screen)
+	if g.showCentroid {
+		g.drawCentroid(screen)
+	}
+	if g.showZones {
+		g.drawZones(screen)
+	}
+	g.drawRectangle(screen)
+}
+
+// advance cycles one generation of a running game and updates the
+// detectors that follow it generation by generation.
+func (g *Game) advance() {
+	g.cycle()
+	g.generationRate.add(1)
+	population := g.Population()
+	g.updateSession(population, g.populations.size > 0 && g.populations.latest() > 0)
+	g.populations.add(g.generation, population)
+	g.detectPeriod()
+	if g.slowMotion {
+		g.updateSlowMotion(population)
+	}
+	if g.cameraLocked {
+		g.centerOnLiveCells()
+	}
+	if g.showCentroid || g.followingCentroid {
+		g.trackCentroid()
+	}
+	if g.ruleEvolutionInterval > 0 && g.mode == ModeLife && g.generation%g.ruleEvolutionInterval == 0 {
+		g.evolveRule()
+	}
 }
 
 func (g *Game) cycle() {
-	var newGrid [maxColumns][maxRows]bool
+	g.lastEditTime = time.Time{}
+	if g.journal != nil {
+		g.journalChanges(journalEdit)
+	}
+	if g.playback != nil {
+		g.advancePlayback()
+		g.generation++
+		if g.journal != nil {
+			g.journalChanges(journalGeneration)
+		}
+		return
+	}
+	switch g.mode {
+	case ModeWireWorld:
+		g.cycleWireWorld()
+	case ModeBriansBrain:
+		g.cycleBriansBrain()
+	case ModeElementary1D:
+		g.cycleElementary()
+	default:
+		prev := g.currentGrid
+		g.cycleLife()
+		if g.sound != nil {
+			g.sound.play(g.countTransitions(prev))
+		}
+	}
+	g.generation++
+	g.cellRate.add(g.columns * g.rows)
+	if g.history != nil {
+		g.recordHistoryFrame()
+	}
+	if g.journal != nil {
+		g.journalChanges(journalGeneration)
+	}
+}
+
+func (g *Game) cycleLife() {
+	g.pool.run(g.rows, 4*g.workers)
+	g.currentGrid = g.nextGrid()
+}
+
+func (g *Game) cycleLifeBand(band CycleBand) {
+	newGrid := g.nextGrid()
 	for i := 0; i < g.columns; i++ {
-		for j := 0; j < g.rows; j++ {
-			count := g.countLiveNeighbors(i, j)
-			if g.grid[i][j] {
-				newGrid[i][j] = count == 2 || count == 3
+		for j := band.StartRow; j < band.EndRow; j++ {
+			newGrid[i][j] = g.nextLifeState(g.ruleTableAt(i, j), i, j)
+			g.ages[i][j] = g.nextAge(i, j, newGrid[i][j])
+			if newGrid[i][j] || g.currentGrid[i][j] {
+				g.everAlive[i][j] = true
+			}
+			if !newGrid[i][j] || !g.currentGrid[i][j] {
+				g.everDead[i][j] = true
+			}
+		}
+	}
+}
+
+// Close stops the cycle worker pool and finishes any history recording.
+// Games that are created for headless use should be closed once they are no
+// longer needed.
+func (g *Game) Close() {
+	g.pool.close()
+	if err := g.StopRecordingHistory(); err != nil {
+		log.Printf("failed to finish the history recording: %v", err)
+	}
+	if err := g.StopJournal(); err != nil {
+		log.Printf("failed to finish the journal: %v", err)
+	}
+}
+
+func (g *Game) nextGrid() *Grid {
+	if g.currentGrid == &g.gridA {
+		return &g.gridB
+	}
+	return &g.gridA
+}
+
+func (g *Game) nextStates() *[maxColumns][maxRows]uint8 {
+	if g.states == &g.statesA {
+		return &g.statesB
+	}
+	return &g.statesA
+}
+
+// Step advances the simulation by one generation regardless of its state,
+// which also makes it usable without running the game loop.
+func (g *Game) Step() {
+	g.cycle()
+}
+
+// Randomize fills the grid with live cells at 50% density using the game's
+// seeded random source, or in the multi-state modes gives every cell one of
+// the mode's states at random. With a symmetry other than SymmetryNone only
+// one half or quarter of the grid is random and the rest mirrors it.
+func (g *Game) Randomize(s Symmetry) {
+	g.hardReset()
+	columns, rows := g.columns, g.rows
+	if s == SymmetryHorizontal || s == SymmetryFourFold {
+		columns = (columns + 1) / 2
+	}
+	if s == SymmetryVertical || s == SymmetryFourFold {
+		rows = (rows + 1) / 2
+	}
+	for i := 0; i < columns; i++ {
+		for j := 0; j < rows; j++ {
+			if g.multiState() {
+				g.states[i][j] = uint8(g.rng.Intn(int(g.stateCount())))
 			} else {
-				newGrid[i][j] = count == 3
+				g.currentGrid[i][j] = g.rng.Intn(2) == 1
 			}
 		}
 	}
-	g.grid = newGrid
-	g.generation++
+	g.Symmetrize(s)
+}
+
+// SetGeneration changes the displayed generation counter without touching
+// the grid, e.g. to line it up with an external reference.
+func (g *Game) SetGeneration(n int) {
+	g.generation = max(n, 0)
+}
+
+func (g *Game) Seed() int64 {
+	return g.seed
+}
+
+func (g *Game) ruleString() string {
+	switch g.mode {
+	case ModeWireWorld:
+		return "WireWorld"
+	case ModeBriansBrain:
+		return "B2/S/C3"
+	case ModeElementary1D:
+		return fmt.Sprintf("W%d", g.elementaryRule)
+	default:
+		if g.gridTopology == TopologyHex {
+			return g.rule.String() + "H"
+		}
+		return g.rule.String()
+	}
+}
+
+func (g *Game) ruleName() string {
+	if g.mode == ModeLife && g.rule.Name != "" {
+		return fmt.Sprintf("%s (%s)", g.rule.Name, g.ruleString())
+	}
+	return g.ruleString()
 }
 
 func (g *Game) countLiveNeighbors(x, y int) int {
+	if g.gridTopology == TopologyHex {
+		return g.countHexNeighbors(x, y)
+	}
 	count := 0
 	for i := -1; i <= 1; i++ {
 		for j := -1; j <= 1; j++ {
 			if i == 0 && j == 0 {
 				continue
 			}
-			nx, ny := x+i, y+j
-			if nx >= 0 && nx < g.columns && ny >= 0 && ny < g.rows {
-				if g.grid[nx][ny] {
+			if nx, ny, ok := g.neighbor(x, y, i, j); ok {
+				if g.currentGrid[nx][ny] {
 					count++
 				}
 			}
@@ -172,9 +1197,38 @@ func (g *Game) countLiveNeighbors(x, y int) int {
 }
 
 func (g *Game) Layout(w, h int) (int, int) {
+	if w != g.screenWidth || max(h-StatusBarHeight, 1) != g.screenHeight {
+		g.resize(w, max(h-StatusBarHeight, 1))
+	}
 	return w, h
 }
 
+// resize fits the grid to a new logical size of the area above the status
+// bar. The grid storage is sized for the default screen, so larger windows
+// leave a margin.
+func (g *Game) resize(w, h int) {
+	g.screenWidth, g.screenHeight = w, h
+	g.updateRenderScale()
+	g.columns = max(min(w/g.cellSize, maxColumns), 1)
+	g.rows = max(min(h/g.cellSize, maxRows), 1)
+	g.clearInactiveCells()
+	g.Pan(0, 0)
+}
+
+// clearInactiveCells kills cells outside the active columns and rows, in
+// both buffers, so they cannot reappear when the grid grows again.
+func (g *Game) clearInactiveCells() {
+	for i := 0; i < maxColumns; i++ {
+		for j := 0; j < maxRows; j++ {
+			if i >= g.columns || j >= g.rows {
+				g.gridA[i][j], g.gridB[i][j] = false, false
+				g.statesA[i][j], g.statesB[i][j] = WireEmpty, WireEmpty
+				g.ages[i][j] = 0
+			}
+		}
+	}
+}
+
 func (g *Game) toggleState() {
 	if g.state == Paused {
 		g.state = Running
@@ -184,20 +1238,52 @@ func (g *Game) toggleState() {
 	g.ticks = 0
 }
 
-func (g *Game) reset() {
+func (g *Game) hardReset() {
+	g.summarizeSession()
+	g.pushUndo()
 	for i := 0; i < g.columns; i++ {
 		for j := 0; j < g.rows; j++ {
-			g.grid[i][j] = false
+			g.currentGrid[i][j] = false
+			g.states[i][j] = WireEmpty
+			g.ages[i][j] = 0
+			g.everAlive[i][j] = false
+			g.everDead[i][j] = false
 			g.generation = 0
 		}
+		g.elementarySeed[i] = false
+	}
+	g.centroid = centroidTracker{}
+}
+
+// SoftReset restarts the run from generation 0 without touching the cells,
+// so that a drawn pattern can be replayed. Cell ages and the detectors that
+// follow the run start over as they do after hardReset.
+func (g *Game) SoftReset() {
+	g.generation = 0
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			g.ages[i][j] = 0
+			g.everAlive[i][j] = g.currentGrid[i][j]
+			g.everDead[i][j] = !g.currentGrid[i][j]
+		}
 	}
+	g.populations = populationWindow{}
+	g.periods = newPeriodDetector(len(g.periods.hashes))
+	g.centroid = centroidTracker{}
 }
 
 func (g *Game) switchTheme() {
-	if g.selectedThemeID == Dark {
-		g.selectedThemeID = Light
+	g.selectedThemeID = (g.selectedThemeID + 1) % ThemeID(len(g.themes))
+	if g.invertedTheme != nil {
+		g.invertedTheme = g.themes[g.selectedThemeID].inverted()
+	}
+}
+
+func (g *Game) toggleInvertedTheme() {
+	if g.invertedTheme != nil {
+		g.invertedTheme = nil
 	} else {
-		g.selectedThemeID = Dark
+		g.invertedTheme = g.themes[g.selectedThemeID].inverted()
 	}
 }
 
@@ -205,10 +1291,32 @@ func (g *Game) drawBackground(screen *ebiten.Image) {
 	screen.Fill(g.theme().BackgroundColor)
 }
 
+// drawDeadCells fills the play area with c in one draw call; live cells are
+// drawn over it, which leaves every dead cell in c without a rectangle each.
+func (g *Game) drawDeadCells(screen *ebiten.Image, c color.Color) {
+	size := g.drawCellSize()
+	border := float32(g.borderCells) * size
+	width, height := float32(g.columns)*size-2*border, float32(g.rows)*size-2*border
+	vector.DrawFilledRect(screen, border, border, width, height, c, false)
+}
+
+func (g *Game) drawBorder(screen *ebiten.Image) {
+	if g.borderCells == 0 {
+		return
+	}
+	c := g.theme().BorderColor
+	size := g.drawCellSize()
+	border := float32(g.borderCells) * size
+	width, height := float32(g.columns)*size, float32(g.rows)*size
+	vector.DrawFilledRect(screen, 0, 0, width, border, c, false)
+	vector.DrawFilledRect(screen, 0, height-border, width, border, c, false)
+	vector.DrawFilledRect(screen, 0, border, border, height-2*border, c, false)
+	vector.DrawFilledRect(screen, width-border, border, border, height-2*border, c, false)
+}
+
 func (g *Game) theme() *Theme {
-	if g.selectedThemeID == Light {
-		return g.lightTheme
-	} else {
-		return g.darkTheme
+	if g.invertedTheme != nil {
+		return g.invertedTheme
 	}
+	return g.themes[g.selectedThemeID]
 }
@@ -0,0 +1,32 @@
+package game_test
+
+import (
+	"gameoflife/game"
+	"testing"
+)
+
+// newGame creates a headless game that is closed when the test finishes.
+func newGame(tb testing.TB, options game.Options) *game.Game {
+	tb.Helper()
+	if options.CellSize == 0 {
+		options.CellSize = 10
+	}
+	g := game.NewFromOptions(options)
+	tb.Cleanup(g.Close)
+	return g
+}
+
+// stamp places an RLE pattern with its top-left corner at (x, y).
+func stamp(tb testing.TB, g *game.Game, rle string, x, y int) {
+	tb.Helper()
+	if err := g.StampRLE([]byte(rle), x, y); err != nil {
+		tb.Fatalf("StampRLE(%q): %v", rle, err)
+	}
+}
+
+// step advances g by n generations.
+func step(g *game.Game, n int) {
+	for range n {
+		g.Step()
+	}
+}
@@ -0,0 +1,34 @@
+package game_test
+
+import (
+	"gameoflife/game"
+	"gameoflife/game/testutil"
+	"testing"
+)
+
+// TestGliderDisplacement checks that after one period a glider is the same
+// shape moved one cell diagonally.
+func TestGliderDisplacement(t *testing.T) {
+	g := newGame(t, game.Options{Topology: game.TopologyBounded})
+	stamp(t, g, glider, 5, 5)
+	testutil.AssertGridEquals(t, g.CurrentGrid(), `
+		.........
+		.........
+		.........
+		.........
+		.........
+		......O..
+		.......O.
+		.....OOO.`)
+	step(g, 4)
+	testutil.AssertGridEquals(t, g.CurrentGrid(), `
+		.........
+		.........
+		.........
+		.........
+		.........
+		.........
+		.......O.
+		........O
+		......OOO`)
+}
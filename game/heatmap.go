@@ -0,0 +1,80 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// ExportHeatmap writes the grid to path as a PNG with one pixel per cell,
+// white for live cells and black for dead ones.
+func (g *Game) ExportHeatmap(path string) error {
+	return writePNG(path, g.heatmap(func(x, y int) uint8 {
+		if g.isAlive(x, y) {
+			return 0xff
+		}
+		return 0
+	}))
+}
+
+// ExportAgeHeatmap is ExportHeatmap with live cells shaded by age, from dark
+// gray for newborn cells to white for the oldest cell on the grid.
+func (g *Game) ExportAgeHeatmap(path string) error {
+	oldest := 0
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			oldest = max(oldest, g.CellAge(i, j))
+		}
+	}
+	return writePNG(path, g.heatmap(func(x, y int) uint8 {
+		age := g.CellAge(x, y)
+		if age == 0 {
+			return 0
+		}
+		return uint8(newbornShade + (age-1)*(0xff-newbornShade)/max(oldest-1, 1))
+	}))
+}
+
+// newbornShade is the gray of one generation old cells in ExportAgeHeatmap,
+// kept apart from the black of dead cells.
+const newbornShade = 0x40
+
+func (g *Game) heatmap(shade func(x, y int) uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, g.columns, g.rows))
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			y := shade(i, j)
+			img.SetNRGBA(i, j, color.NRGBA{R: y, G: y, B: y, A: 0xff})
+		}
+	}
+	return img
+}
+
+// ImportHeatmap replaces the grid with a PNG image scaled to it, with
+// nearest-neighbor sampling, where pixels brighter than mid gray are live
+// cells.
+func (g *Game) ImportHeatmap(r io.Reader) error {
+	if g.mode != ModeLife {
+		return errors.New("heatmaps can only be imported in Life mode")
+	}
+	img, err := png.Decode(r)
+	if err != nil {
+		return fmt.Errorf("failed to decode heatmap: %w", err)
+	}
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return errors.New("heatmap is empty")
+	}
+	g.hardReset()
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			x := bounds.Min.X + (2*i+1)*bounds.Dx()/(2*g.columns)
+			y := bounds.Min.Y + (2*j+1)*bounds.Dy()/(2*g.rows)
+			g.currentGrid[i][j] = color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y > 128
+		}
+	}
+	return nil
+}
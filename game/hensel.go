@@ -0,0 +1,82 @@
+package game
+
+// Neighborhoods are 9 bit masks of the 3x3 block around a cell in row-major
+// order, so bit 0 is the north-west neighbor and bit 4, the cell itself, is
+// never set.
+const allNeighbors = 0x1ef
+
+// henselLetters are the configuration letters of Hensel notation for each
+// neighbor count, in canonical order. Together they name the 51 ways of
+// arranging live neighbors up to rotation and reflection.
+var henselLetters = [9]string{"", "ce", "ceaikn", "ceaiknjqry", "ceaiknjqrytwz", "ceaiknjqry", "ceaikn", "ce", ""}
+
+// henselRepresentatives holds one neighborhood per letter of
+// henselLetters[1] to henselLetters[4]. Counts above 4 use the complement
+// of the configuration with the same letter for 8 minus the count.
+var henselRepresentatives = [5][]int{
+	1: {0x001, 0x002},
+	2: {0x005, 0x00a, 0x003, 0x028, 0x021, 0x044},
+	3: {0x045, 0x02a, 0x00b, 0x007, 0x062, 0x00d, 0x00e, 0x046, 0x029, 0x061},
+	4: {0x145, 0x0aa, 0x00f, 0x02d, 0x063, 0x047, 0x06a, 0x066, 0x02b, 0x065, 0x069, 0x04e, 0x06c},
+}
+
+// henselNeighborhoods lists every neighborhood with count live neighbors
+// arranged as the configuration named by letter, which must be one of
+// henselLetters[count].
+func henselNeighborhoods(count int, letter byte) []int {
+	index := 0
+	for henselLetters[count][index] != letter {
+		index++
+	}
+	var representative int
+	if count <= 4 {
+		representative = henselRepresentatives[count][index]
+	} else {
+		representative = allNeighbors &^ henselRepresentatives[8-count][index]
+	}
+	var neighborhoods []int
+	seen := make(map[int]bool)
+	for _, mirrored := range [2]bool{false, true} {
+		mask := representative
+		if mirrored {
+			mask = transformNeighborhood(mask, func(x, y int) (int, int) { return 2 - x, y })
+		}
+		for range 4 {
+			if !seen[mask] {
+				seen[mask] = true
+				neighborhoods = append(neighborhoods, mask)
+			}
+			mask = transformNeighborhood(mask, func(x, y int) (int, int) { return 2 - y, x })
+		}
+	}
+	return neighborhoods
+}
+
+// transformNeighborhood moves every neighbor of mask by f, which maps
+// positions in the 3x3 block onto each other.
+func transformNeighborhood(mask int, f func(x, y int) (int, int)) int {
+	transformed := 0
+	for bit := range 9 {
+		if mask&(1<<bit) != 0 {
+			x, y := f(bit%3, bit/3)
+			transformed |= 1 << (y*3 + x)
+		}
+	}
+	return transformed
+}
+
+// neighborhood is the mask of the live neighbors of the cell at (x, y).
+func (g *Game) neighborhood(x, y int) int {
+	mask := 0
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+			if nx, ny, ok := g.neighbor(x, y, dx, dy); ok && g.currentGrid[nx][ny] {
+				mask |= 1 << ((dy+1)*3 + dx + 1)
+			}
+		}
+	}
+	return mask
+}
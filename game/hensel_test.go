@@ -0,0 +1,100 @@
+package game
+
+import (
+	"math/bits"
+	"testing"
+)
+
+func TestParseRuleHensel(t *testing.T) {
+	for _, tt := range []struct {
+		rule string
+		want Rule
+	}{
+		{"B3/S23", Rule{Born: neighborCounts(3), Survive: neighborCounts(2, 3)}},
+		{"s23/b3", Rule{Born: neighborCounts(3), Survive: neighborCounts(2, 3)}},
+		{"B2-a/S12", Rule{Born: neighborCounts(2), Survive: neighborCounts(1, 2), BornLetters: [9]string{2: "ceikn"}}},
+		{"B2ae/S3-jqr4z", Rule{Born: neighborCounts(2), Survive: neighborCounts(3, 4), BornLetters: [9]string{2: "ea"}, SurviveLetters: [9]string{3: "ceaikny", 4: "z"}}},
+		{"B3/S2-i34q", Rule{Born: neighborCounts(3), Survive: neighborCounts(2, 3, 4), SurviveLetters: [9]string{2: "ceakn", 4: "q"}}},
+		// Naming every configuration of a count is the same as naming none.
+		{"B3ceaiknjqry/S2ceaikn3", Rule{Born: neighborCounts(3), Survive: neighborCounts(2, 3)}},
+		{"B2a2e/S", Rule{Born: neighborCounts(2), BornLetters: [9]string{2: "ea"}}},
+		{"B08/S1ce", Rule{Born: neighborCounts(0, 8), Survive: neighborCounts(1)}},
+	} {
+		got, err := ParseRule(tt.rule)
+		if err != nil {
+			t.Errorf("ParseRule(%q): %v", tt.rule, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRule(%q) = %+v, want %+v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestParseRuleHenselErrors(t *testing.T) {
+	for _, rule := range []string{
+		"B2aa/S",   // duplicate letter
+		"B2-cc/S",  // duplicate negated letter
+		"B2x/S",    // not a configuration of 2
+		"B3/S2z",   // z only names a configuration of 4
+		"B0c/S",    // 0 neighbors have a single arrangement
+		"B3/S8e",   // and so do 8
+		"B2-/S",    // negates nothing
+		"B9/S23",   // no such count
+		"B3/S23/C", // not B/S
+	} {
+		if got, err := ParseRule(rule); err == nil {
+			t.Errorf("ParseRule(%q) = %s, want an error", rule, got)
+		}
+	}
+}
+
+// TestHenselLettersPartitionNeighborhoods checks that the letters of every
+// count name each neighborhood with that many live neighbors exactly once,
+// so that a rule naming every letter of B3/S23 gives Conway's table.
+func TestHenselLettersPartitionNeighborhoods(t *testing.T) {
+	lettered := Rule{Born: Conway.Born, Survive: Conway.Survive}
+	for count := range 9 {
+		seen := map[int]byte{}
+		for _, letter := range []byte(henselLetters[count]) {
+			for _, mask := range henselNeighborhoods(count, letter) {
+				if mask&^allNeighbors != 0 || bits.OnesCount(uint(mask)) != count {
+					t.Errorf("%d%c includes neighborhood %#03x", count, letter, mask)
+				}
+				if other, ok := seen[mask]; ok {
+					t.Errorf("neighborhood %#03x is both %d%c and %d%c", mask, count, other, count, letter)
+				}
+				seen[mask] = letter
+			}
+		}
+		if want := binomial(8, count); count > 0 && count < 8 && len(seen) != want {
+			t.Errorf("letters of %d name %d neighborhoods, want %d", count, len(seen), want)
+		}
+		lettered.BornLetters[count] = henselLetters[count]
+		lettered.SurviveLetters[count] = henselLetters[count]
+	}
+
+	table := precomputeRuleTable(lettered)
+	if table.neighborhoods == nil {
+		t.Fatal("lettered rule was treated as totalistic")
+	}
+	for mask := range 512 {
+		if mask&^allNeighbors != 0 {
+			continue
+		}
+		count := bits.OnesCount(uint(mask))
+		for state, want := range [2]bool{Conway.Born[count], Conway.Survive[count]} {
+			if got := table.neighborhoods[state][mask]; got != want {
+				t.Errorf("state %d neighborhood %#03x = %t, want %t as in Conway's table", state, mask, got, want)
+			}
+		}
+	}
+}
+
+func binomial(n, k int) int {
+	result := 1
+	for i := range k {
+		result = result * (n - i) / (i + 1)
+	}
+	return result
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+)
+
+// GridTopology is the shape of the cells tiling the grid.
+type GridTopology int
+
+const (
+	TopologySquare GridTopology = iota
+	TopologyHex
+	gridTopologyCount
+)
+
+func (t GridTopology) String() string {
+	switch t {
+	case TopologySquare:
+		return "Square"
+	case TopologyHex:
+		return "Hex"
+	default:
+		return "Unknown"
+	}
+}
+
+func (t GridTopology) MarshalText() ([]byte, error) {
+	return enumText(t, gridTopologyCount)
+}
+
+func (t *GridTopology) UnmarshalText(text []byte) error {
+	return parseEnumText(t, text, gridTopologyCount)
+}
+
+// checkRule reports why rule cannot be used on grids of topology t, if it
+// cannot: hex cells have six neighbors and no letters for non-totalistic
+// neighborhoods.
+func (t GridTopology) checkRule(rule Rule) error {
+	if t != TopologyHex {
+		return nil
+	}
+	if rule.maxCount() > 6 {
+		return fmt.Errorf("hex rules count at most 6 neighbors, got %s", rule)
+	}
+	if !rule.totalistic() {
+		return fmt.Errorf("hex grids only support totalistic rules, got %s", rule)
+	}
+	return nil
+}
+
+// HexRule is a well-known hexagonal Life rule with a good mix of still
+// lifes and oscillators.
+var HexRule = Rule{Name: "Hex Life", Born: neighborCounts(2), Survive: neighborCounts(3, 4)}
+
+// hexNeighbors are the six neighbor offsets in "odd-r" offset coordinates,
+// where odd rows are shifted half a cell to the right, indexed by row
+// parity.
+var hexNeighbors = [2][6][2]int{
+	{{-1, 0}, {1, 0}, {-1, -1}, {0, -1}, {-1, 1}, {0, 1}},
+	{{-1, 0}, {1, 0}, {0, -1}, {1, -1}, {0, 1}, {1, 1}},
+}
+
+func (g *Game) countHexNeighbors(x, y int) int {
+	count := 0
+	for _, offset := range hexNeighbors[y%2] {
+		if nx, ny, ok := g.neighbor(x, y, offset[0], offset[1]); ok {
+			if g.currentGrid[nx][ny] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// alignRow rounds a pattern origin row down to an even row on hex grids.
+// Odd rows are offset, so moving a pattern by an odd number of rows would
+// change its shape.
+func (g *Game) alignRow(y int) int {
+	if g.gridTopology == TopologyHex {
+		return y &^ 1
+	}
+	return y
+}
+
+// hexCorners returns the corners of the pointy-top hexagon for cell (i, j).
+// Hexagons are a cell wide and overlap the rows above and below by a sixth
+// of a cell so that neighboring rows interlock.
+func (g *Game) hexCorners(i, j int) []float32 {
+	x, y := g.hexOrigin(i, j)
+	return hexagon(x, y, g.drawCellSize())
+}
+
+// hexOrigin is the top-left corner of the box cell (i, j) is drawn in.
+func (g *Game) hexOrigin(i, j int) (float32, float32) {
+	size := g.drawCellSize()
+	x, y := float32(i)*size, float32(j)*size
+	if j%2 == 1 {
+		x += size / 2
+	}
+	return x, y
+}
+
+// hexagon returns the corners of the hexagon drawn in the size-wide box
+// whose top-left corner is at (x, y).
+func hexagon(x, y, size float32) []float32 {
+	return []float32{
+		x + size/2, y - size/6,
+		x + size, y + size/6,
+		x + size, y + 5*size/6,
+		x + size/2, y + 7*size/6,
+		x, y + 5*size/6,
+		x, y + size/6,
+	}
+}
+
+// hexOutlineChunk is the number of hexagons stroked per draw call; it keeps
+// each chunk's vertex count within the 16-bit index range.
+const hexOutlineChunk = 512
+
+type hexMesh struct {
+	vertices []ebiten.Vertex
+	indices  []uint16
+}
+
+// hexOutline caches the stroked outline of every hexagon, which is
+// expensive to tessellate and only changes with the grid size.
+type hexOutline struct {
+	cellSize float32
+	columns  int
+	rows     int
+	color    color.Color
+	meshes   []hexMesh
+}
+
+func (g *Game) drawHexGrid(screen *ebiten.Image) {
+	theme := g.theme()
+	if !g.gridLinesHidden() {
+		g.drawHexOutline(screen)
+	}
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if g.currentGrid[i][j] {
+				x, y := g.hexOrigin(i, j)
+				g.drawSprite(screen, x, y, spriteKey{hex: true, color: theme.CellColor, fill: g.cellFill})
+			}
+		}
+	}
+}
+
+func (g *Game) drawHexOutline(screen *ebiten.Image) {
+	theme := g.theme()
+	o := &g.hexOutline
+	if o.cellSize != g.drawCellSize() || o.columns != g.columns || o.rows != g.rows {
+		o.meshes = o.meshes[:0]
+		var path vector.Path
+		hexes := 0
+		flush := func() {
+			var m hexMesh
+			m.vertices, m.indices = path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{Width: 1})
+			o.meshes = append(o.meshes, m)
+			path, hexes = vector.Path{}, 0
+		}
+		for i := 0; i < g.columns; i++ {
+			for j := 0; j < g.rows; j++ {
+				polygonPath(&path, g.hexCorners(i, j))
+				if hexes++; hexes == hexOutlineChunk {
+					flush()
+				}
+			}
+		}
+		if hexes > 0 {
+			flush()
+		}
+		o.cellSize, o.columns, o.rows, o.color = g.drawCellSize(), g.columns, g.rows, nil
+	}
+	if o.color != theme.GridColor {
+		for _, m := range o.meshes {
+			colorVertices(m.vertices, theme.GridColor)
+		}
+		o.color = theme.GridColor
+	}
+	for _, m := range o.meshes {
+		screen.DrawTriangles(m.vertices, m.indices, solidPixel(), &ebiten.DrawTrianglesOptions{AntiAlias: true})
+	}
+}
+
+// hexCellAt maps a screen position to the hex cell under it, treating each
+// hexagon as the cell-sized box it is drawn in.
+func (g *Game) hexCellAt(x, y int) (int, int, bool) {
+	cellY := y / g.cellSize
+	if cellY%2 == 1 {
+		x -= g.cellSize / 2
+	}
+	if x < 0 {
+		return 0, 0, false
+	}
+	cellX := x / g.cellSize
+	return cellX, cellY, cellX < g.columns && cellY < g.rows
+}
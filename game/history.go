@@ -0,0 +1,140 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// historyMagic starts every history file. It is followed by the size of a
+// frame as a big-endian uint32 and then by one MarshalGrid frame per
+// generation, starting with the one recording began at.
+var historyMagic = []byte("GOLHIST2")
+
+type historyRecorder struct {
+	f *os.File
+	w *bufio.Writer
+	// header is the MarshalGrid header of the first frame. The grid must
+	// keep its size for the frames to line up.
+	header []byte
+}
+
+// RecordHistory writes the current grid and every later generation to path
+// until StopRecordingHistory or Close is called. Frames hold the grid as
+// packed by MarshalGrid, so Wire World states are not recorded.
+func (g *Game) RecordHistory(path string) error {
+	if err := g.StopRecordingHistory(); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	frame := g.MarshalGrid()
+	h := &historyRecorder{f: f, w: bufio.NewWriter(f), header: frame[:gridHeaderBytes]}
+	h.w.Write(historyMagic)
+	binary.Write(h.w, binary.BigEndian, uint32(len(frame)))
+	if _, err := h.w.Write(frame); err != nil {
+		f.Close()
+		return err
+	}
+	g.history = h
+	return nil
+}
+
+func (g *Game) StopRecordingHistory() error {
+	h := g.history
+	if h == nil {
+		return nil
+	}
+	g.history = nil
+	if err := h.w.Flush(); err != nil {
+		h.f.Close()
+		return err
+	}
+	return h.f.Close()
+}
+
+func (g *Game) recordHistoryFrame() {
+	frame := g.MarshalGrid()
+	if !bytes.Equal(frame[:gridHeaderBytes], g.history.header) {
+		log.Print("history recording stopped: the grid was resized")
+		g.StopRecordingHistory()
+		return
+	}
+	if _, err := g.history.w.Write(frame); err != nil {
+		log.Printf("history recording stopped: %v", err)
+		g.StopRecordingHistory()
+	}
+}
+
+// PlaybackHistory replays a file written by RecordHistory. It restores the
+// first frame and pauses; from then on each generation, whether run or
+// stepped, restores the next frame instead of computing it, until the frames
+// run out.
+func (g *Game) PlaybackHistory(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	frames, err := parseHistory(data)
+	if err != nil {
+		return err
+	}
+	if len(frames) == 0 {
+		return fmt.Errorf("history %s has no frames", path)
+	}
+	if err := g.checkMarshaledGrid(frames[0]); err != nil {
+		return fmt.Errorf("history %s does not fit the grid: %w", path, err)
+	}
+	g.hardReset()
+	g.state = Paused
+	g.playback = frames
+	g.advancePlayback()
+	g.generation = 0
+	return nil
+}
+
+func parseHistory(data []byte) ([][]byte, error) {
+	r := bytes.NewReader(data)
+	magic := make([]byte, len(historyMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, historyMagic) {
+		return nil, fmt.Errorf("not a history file")
+	}
+	var frameSize uint32
+	if err := binary.Read(r, binary.BigEndian, &frameSize); err != nil {
+		return nil, err
+	}
+	if frameSize < gridHeaderBytes {
+		return nil, fmt.Errorf("history frames of %d bytes are too small", frameSize)
+	}
+	size := int(frameSize)
+	if r.Len()%size != 0 {
+		return nil, fmt.Errorf("history ends with a partial frame")
+	}
+	var frames [][]byte
+	for rest := data[len(data)-r.Len():]; len(rest) > 0; rest = rest[size:] {
+		frames = append(frames, rest[:size])
+	}
+	return frames, nil
+}
+
+// advancePlayback restores the next frame, ending playback and pausing after
+// the last one.
+func (g *Game) advancePlayback() {
+	if err := g.UnmarshalGrid(g.playback[0]); err != nil {
+		log.Printf("history playback stopped: %v", err)
+		g.playback = nil
+		g.state = Paused
+		return
+	}
+	g.playback = g.playback[1:]
+	if len(g.playback) == 0 {
+		g.playback = nil
+		g.state = Paused
+	}
+}
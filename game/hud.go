@@ -0,0 +1,88 @@
+package game
+
+import (
+	"bytes"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font/gofont/goregular"
+	"image/color"
+	"log"
+)
+
+// HUDPosition is the corner of the screen the HUD text is drawn in.
+type HUDPosition int
+
+const (
+	HUDTopLeft HUDPosition = iota
+	HUDTopRight
+	HUDBottomLeft
+	HUDBottomRight
+)
+
+func (p HUDPosition) String() string {
+	switch p {
+	case HUDTopLeft:
+		return "Top Left"
+	case HUDTopRight:
+		return "Top Right"
+	case HUDBottomLeft:
+		return "Bottom Left"
+	case HUDBottomRight:
+		return "Bottom Right"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	defaultHUDFontSize = 12
+	hudMargin          = 16
+	// hudPadding is the space between the text and the edge of the
+	// background behind it.
+	hudPadding = 6
+)
+
+var hudBackground = color.NRGBA{0, 0, 0, 180}
+
+var hudFaceSource *text.GoTextFaceSource
+
+func hudFace(size int) *text.GoTextFace {
+	if hudFaceSource == nil {
+		source, err := text.NewGoTextFaceSource(bytes.NewReader(goregular.TTF))
+		if err != nil {
+			log.Fatalf("failed to load the HUD font: %v", err)
+		}
+		hudFaceSource = source
+	}
+	return &text.GoTextFace{Source: hudFaceSource, Size: float64(size)}
+}
+
+// drawHUDText draws msg on a translucent background in the corner of the
+// screen given by Options.HUDPosition, above the status bar.
+func (g *Game) drawHUDText(screen *ebiten.Image, msg string) {
+	width, height := text.Measure(msg, g.hudFace, g.hudLineSpacing())
+	x, y := float64(hudMargin), float64(hudMargin)
+	if g.hudPosition == HUDTopRight || g.hudPosition == HUDBottomRight {
+		x = float64(screen.Bounds().Dx()) - width - hudMargin
+	}
+	if g.hudPosition == HUDBottomLeft || g.hudPosition == HUDBottomRight {
+		y = float64(g.screenHeight) - height - hudMargin
+	}
+	vector.DrawFilledRect(screen, float32(x-hudPadding), float32(y-hudPadding), float32(width+2*hudPadding), float32(height+2*hudPadding), hudBackground, false)
+	g.drawLabel(screen, msg, x, y)
+}
+
+func (g *Game) hudLineSpacing() float64 {
+	return g.hudFace.Size * 1.25
+}
+
+// drawLabel draws msg in white in the HUD font with its top-left corner at
+// (x, y).
+func (g *Game) drawLabel(dst *ebiten.Image, msg string, x, y float64) {
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(x, y)
+	op.LineSpacing = g.hudLineSpacing()
+	op.ColorScale.ScaleWithColor(color.White)
+	text.Draw(dst, msg, g.hudFace, op)
+}
@@ -0,0 +1,163 @@
+package game
+
+import (
+	"bytes"
+	"cmp"
+	"embed"
+	"hash/fnv"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+)
+
+//go:embed objects
+var objectCatalog embed.FS
+
+// maxObjectPeriod is the most generations of each catalog object that are
+// run to collect its phases, enough for the pentadecathlon.
+const maxObjectPeriod = 15
+
+// unknownObject is what IdentifyObject reports for unrecognized cells.
+const unknownObject = "unknown"
+
+var (
+	objectNamesOnce sync.Once
+	// objectNames maps the objectHash of every phase of the catalog objects
+	// to their names; maxObjectPopulation is the largest of those phases.
+	objectNames         map[uint64]string
+	maxObjectPopulation int
+)
+
+// IdentifyObject names the object the live cells form, such as "glider" or
+// "beehive", when they match a phase of one of the catalog objects in any
+// position and orientation, and returns "unknown" otherwise. The catalog is
+// evolved under Conway's rule, so only Life games on square grids running
+// it, without zones, are recognized. Objects split by a wrapping edge are
+// not recognized.
+func (g *Game) IdentifyObject() string {
+	if !g.runsConway() {
+		return unknownObject
+	}
+	names := catalogObjects()
+	if g.Population() > maxObjectPopulation {
+		return unknownObject
+	}
+	if name, ok := names[objectHash(g.currentGrid.LiveCells())]; ok {
+		return name
+	}
+	return unknownObject
+}
+
+// runsConway reports whether the cells evolve under Conway's rule, as the
+// catalog objects do.
+func (g *Game) runsConway() bool {
+	return g.mode == ModeLife && g.gridTopology == TopologySquare && len(g.zones) == 0 && g.rule.totalistic() && g.rule.Born == Conway.Born && g.rule.Survive == Conway.Survive
+}
+
+// catalogObjects reads the embedded catalog, running every object under
+// Conway's rule to hash each of its phases.
+func catalogObjects() map[uint64]string {
+	objectNamesOnce.Do(func() {
+		objectNames = map[uint64]string{}
+		entries, _ := objectCatalog.ReadDir("objects")
+		for _, entry := range entries {
+			data, err := objectCatalog.ReadFile("objects/" + entry.Name())
+			var p *Pattern
+			if err == nil {
+				p, err = ParsePlaintext(data)
+			}
+			if err != nil {
+				log.Fatalf("failed to read object %s: %v", entry.Name(), err)
+			}
+			name := p.Name
+			if name == "" {
+				name = strings.TrimSuffix(entry.Name(), ".cells")
+			}
+			var cells [][2]int
+			for y, row := range p.Cells {
+				for x, state := range row {
+					if state != 0 {
+						cells = append(cells, [2]int{x, y})
+					}
+				}
+			}
+			for range maxObjectPeriod {
+				hash := objectHash(cells)
+				if _, ok := objectNames[hash]; ok {
+					break
+				}
+				objectNames[hash] = name
+				maxObjectPopulation = max(maxObjectPopulation, len(cells))
+				cells = stepObject(cells)
+			}
+		}
+	})
+	return objectNames
+}
+
+// objectHash hashes a set of cells the same way in every position and in
+// all eight orientations, by hashing the smallest encoding of its sorted
+// cells moved to the origin.
+func objectHash(cells [][2]int) uint64 {
+	var canonical []byte
+	transformed := make([][2]int, len(cells))
+	for orientation := range 8 {
+		minX, minY := 0, 0
+		for i, cell := range cells {
+			dx, dy := cell[0], cell[1]
+			if orientation&1 != 0 {
+				dx, dy = dy, dx
+			}
+			if orientation&2 != 0 {
+				dx = -dx
+			}
+			if orientation&4 != 0 {
+				dy = -dy
+			}
+			if i == 0 {
+				minX, minY = dx, dy
+			}
+			minX, minY = min(minX, dx), min(minY, dy)
+			transformed[i] = [2]int{dx, dy}
+		}
+		slices.SortFunc(transformed, func(a, b [2]int) int {
+			return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+		})
+		encoded := make([]byte, 0, 4*len(cells))
+		for _, cell := range transformed {
+			x, y := cell[0]-minX, cell[1]-minY
+			encoded = append(encoded, byte(x>>8), byte(x), byte(y>>8), byte(y))
+		}
+		if canonical == nil || bytes.Compare(encoded, canonical) < 0 {
+			canonical = encoded
+		}
+	}
+	h := fnv.New64a()
+	h.Write(canonical)
+	return h.Sum64()
+}
+
+// stepObject computes the next generation of a set of cells on an unbounded
+// grid under Conway's rule.
+func stepObject(cells [][2]int) [][2]int {
+	alive := make(map[[2]int]bool, len(cells))
+	neighbors := map[[2]int]int{}
+	for _, cell := range cells {
+		alive[cell] = true
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				if dx != 0 || dy != 0 {
+					neighbors[[2]int{cell[0] + dx, cell[1] + dy}]++
+				}
+			}
+		}
+	}
+	var next [][2]int
+	for cell, count := range neighbors {
+		if count == 3 || count == 2 && alive[cell] {
+			next = append(next, cell)
+		}
+	}
+	return next
+}
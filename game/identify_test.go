@@ -0,0 +1,22 @@
+package game_test
+
+import (
+	"gameoflife/game"
+	"testing"
+)
+
+func TestIdentifyObjectOnlyUnderConway(t *testing.T) {
+	for _, tt := range []struct {
+		rule, want string
+	}{
+		{"B3/S23", "glider"},
+		{"B36/S23", "unknown"},
+		{"B3/S2-i34q", "unknown"},
+	} {
+		g := newGame(t, game.Options{Rule: tt.rule})
+		stamp(t, g, glider, 10, 10)
+		if got := g.IdentifyObject(); got != tt.want {
+			t.Errorf("IdentifyObject under %s = %q, want %q", tt.rule, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// journalMagic starts every journal. It is followed by the generation the
+// journal started at as a big-endian uint64, the size of a frame as a
+// big-endian uint32, the MarshalGrid frame the journal started at and then
+// one record per generation or batch of edits. A record is a kind byte,
+// the number of cells that flipped as a uvarint and the flipped cells' bit
+// positions in the frame's cells, as uvarint differences from the previous
+// one.
+var journalMagic = []byte("GOLJRNL3")
+
+const (
+	journalGeneration byte = 'G'
+	journalEdit       byte = 'E'
+)
+
+type journal struct {
+	f *os.File
+	w *bufio.Writer
+	// last is the grid as of the last record.
+	last Grid
+	// columns and rows are the size of the grid the journal started with,
+	// which the grid must keep for the bit positions to line up.
+	columns, rows int
+}
+
+// EnableJournal appends the cells that change in every generation, and those
+// changed by edits in between, to path until StopJournal or Close is called.
+// Unlike RecordHistory it only writes the full grid once, at the start.
+func (g *Game) EnableJournal(path string) error {
+	if err := g.StopJournal(); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	frame := g.MarshalGrid()
+	j := &journal{f: f, w: bufio.NewWriter(f), last: *g.currentGrid, columns: g.columns, rows: g.rows}
+	j.w.Write(journalMagic)
+	binary.Write(j.w, binary.BigEndian, uint64(g.generation))
+	binary.Write(j.w, binary.BigEndian, uint32(len(frame)))
+	if _, err := j.w.Write(frame); err != nil {
+		f.Close()
+		return err
+	}
+	g.journal = j
+	return nil
+}
+
+// StopJournal records the edits made since the last record and closes the
+// journal.
+func (g *Game) StopJournal() error {
+	j := g.journal
+	if j == nil {
+		return nil
+	}
+	g.journal = nil
+	if err := j.record(g, journalEdit); err != nil {
+		j.close()
+		return err
+	}
+	return j.close()
+}
+
+func (j *journal) close() error {
+	if err := j.w.Flush(); err != nil {
+		j.f.Close()
+		return err
+	}
+	return j.f.Close()
+}
+
+// journalChanges records the cells that changed since the last record,
+// stopping the journal when that fails.
+func (g *Game) journalChanges(kind byte) {
+	if err := g.journal.record(g, kind); err != nil {
+		log.Printf("journal stopped: %v", err)
+		g.journal.close()
+		g.journal = nil
+	}
+}
+
+// record writes a record of the cells of g that changed since the last one.
+// Edit records are skipped when nothing changed.
+func (j *journal) record(g *Game, kind byte) error {
+	if g.columns != j.columns || g.rows != j.rows {
+		return fmt.Errorf("the grid was resized")
+	}
+	var changed []int
+	for y := 0; y < j.rows; y++ {
+		for x := 0; x < j.columns; x++ {
+			if g.currentGrid[x][y] != j.last[x][y] {
+				changed = append(changed, y*j.columns+x)
+			}
+		}
+	}
+	if kind == journalEdit && len(changed) == 0 {
+		return nil
+	}
+	record := binary.AppendUvarint([]byte{kind}, uint64(len(changed)))
+	previous := 0
+	for _, bit := range changed {
+		record = binary.AppendUvarint(record, uint64(bit-previous))
+		previous = bit
+	}
+	j.last = *g.currentGrid
+	_, err := j.w.Write(record)
+	return err
+}
+
+// ReplayJournal restores the grid a journal written by EnableJournal ends
+// with, and sets the generation to the one the journal started at plus the
+// number of generations it recorded.
+func (g *Game) ReplayJournal(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(data, journalMagic) || len(data) < len(journalMagic)+12 {
+		return fmt.Errorf("not a journal")
+	}
+	data = data[len(journalMagic):]
+	start := int(binary.BigEndian.Uint64(data))
+	frameSize := int(binary.BigEndian.Uint32(data[8:]))
+	data = data[12:]
+	if len(data) < frameSize {
+		return fmt.Errorf("journal is missing its initial frame")
+	}
+	frame := bytes.Clone(data[:frameSize])
+	if err := g.checkMarshaledGrid(frame); err != nil {
+		return fmt.Errorf("journal does not fit the grid: %w", err)
+	}
+	cells := uint64(g.columns * g.rows)
+	generations := 0
+	for rest := bytes.NewReader(data[frameSize:]); rest.Len() > 0; {
+		kind, _ := rest.ReadByte()
+		if kind != journalGeneration && kind != journalEdit {
+			return fmt.Errorf("journal has an unknown record kind %q", kind)
+		}
+		count, err := binary.ReadUvarint(rest)
+		if err != nil {
+			return fmt.Errorf("journal ends with a partial record")
+		}
+		bit := uint64(0)
+		for range count {
+			delta, err := binary.ReadUvarint(rest)
+			if err != nil {
+				return fmt.Errorf("journal ends with a partial record")
+			}
+			if bit += delta; bit >= cells {
+				return fmt.Errorf("journal refers to cell %d outside the grid", bit)
+			}
+			frame[gridHeaderBytes+bit/8] ^= 0x80 >> (bit % 8)
+		}
+		if kind == journalGeneration {
+			generations++
+		}
+	}
+	g.hardReset()
+	if err := g.UnmarshalGrid(frame); err != nil {
+		return err
+	}
+	g.generation = start + generations
+	return nil
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestReplayJournal checks that a replay ends on the journaled grid, edits
+// made just before StopJournal included, at the generation the journal
+// started at plus the ones it recorded.
+func TestReplayJournal(t *testing.T) {
+	g := newRandomGame(t, Options{CellSize: 10, Seed: 1})
+	g.SetGeneration(7)
+	path := filepath.Join(t.TempDir(), "journal")
+	if err := g.EnableJournal(path); err != nil {
+		t.Fatalf("EnableJournal: %v", err)
+	}
+	for range 3 {
+		g.cycle()
+	}
+	g.currentGrid[0][0] = !g.currentGrid[0][0]
+	if err := g.StopJournal(); err != nil {
+		t.Fatalf("StopJournal: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	replayed := NewFromOptions(Options{CellSize: 10})
+	t.Cleanup(replayed.Close)
+	if err := replayed.ReplayJournal(f); err != nil {
+		t.Fatalf("ReplayJournal: %v", err)
+	}
+	if *replayed.currentGrid != *g.currentGrid {
+		t.Error("replayed grid differs from the journaled one")
+	}
+	if replayed.generation != 10 {
+		t.Errorf("replay ends at generation %d, want 10", replayed.generation)
+	}
+}
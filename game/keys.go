@@ -0,0 +1,233 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+type Action int
+
+const (
+	ActionTogglePlay Action = iota
+	ActionReset
+	ActionSwitchTheme
+	ActionSwitchRule
+	ActionSetGeneration
+	ActionStep
+	ActionRandomize
+	ActionPreviewRule
+	ActionFastForward
+	ActionSlowForward
+	ActionToggleAgeHistogram
+	ActionToggleTurbo
+	ActionToggleAutoPause
+	ActionSlowDown
+	ActionSpeedUp
+	ActionPaste
+	ActionTogglePatternedCells
+	ActionToggleVirginCells
+	ActionToggleZoneHighlight
+	ActionColorComponents
+	ActionToggleZones
+	ActionPanUp
+	ActionPanDown
+	ActionPanLeft
+	ActionPanRight
+	ActionLockCamera
+	ActionToggleBigCounter
+	ActionCycleRenderMode
+	ActionInvertTheme
+	ActionStepToPeak
+	ActionStepToTrough
+	ActionVolumeUp
+	ActionVolumeDown
+	ActionToggleQuadrantStats
+	ActionToggleCentroid
+	ActionFollowCentroid
+	ActionSoftReset
+	ActionCopy
+	ActionToggleRendering
+	ActionUndo
+	// ActionEdit has no key; locking it stops mouse edits.
+	ActionEdit
+	ActionListScenarios
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionTogglePlay:
+		return "TogglePlay"
+	case ActionReset:
+		return "Reset"
+	case ActionSwitchTheme:
+		return "SwitchTheme"
+	case ActionSwitchRule:
+		return "SwitchRule"
+	case ActionSetGeneration:
+		return "SetGeneration"
+	case ActionStep:
+		return "Step"
+	case ActionRandomize:
+		return "Randomize"
+	case ActionPreviewRule:
+		return "PreviewRule"
+	case ActionFastForward:
+		return "FastForward"
+	case ActionSlowForward:
+		return "SlowForward"
+	case ActionToggleAgeHistogram:
+		return "ToggleAgeHistogram"
+	case ActionToggleTurbo:
+		return "ToggleTurbo"
+	case ActionToggleAutoPause:
+		return "ToggleAutoPause"
+	case ActionSlowDown:
+		return "SlowDown"
+	case ActionSpeedUp:
+		return "SpeedUp"
+	case ActionPaste:
+		return "Paste"
+	case ActionTogglePatternedCells:
+		return "TogglePatternedCells"
+	case ActionToggleVirginCells:
+		return "ToggleVirginCells"
+	case ActionColorComponents:
+		return "ColorComponents"
+	case ActionToggleZones:
+		return "ToggleZones"
+	case ActionPanUp:
+		return "PanUp"
+	case ActionPanDown:
+		return "PanDown"
+	case ActionPanLeft:
+		return "PanLeft"
+	case ActionPanRight:
+		return "PanRight"
+	case ActionLockCamera:
+		return "LockCamera"
+	case ActionToggleBigCounter:
+		return "ToggleBigCounter"
+	case ActionCycleRenderMode:
+		return "CycleRenderMode"
+	case ActionInvertTheme:
+		return "InvertTheme"
+	case ActionStepToPeak:
+		return "StepToPeak"
+	case ActionStepToTrough:
+		return "StepToTrough"
+	case ActionToggleZoneHighlight:
+		return "ToggleZoneHighlight"
+	case ActionVolumeUp:
+		return "VolumeUp"
+	case ActionVolumeDown:
+		return "VolumeDown"
+	case ActionToggleQuadrantStats:
+		return "ToggleQuadrantStats"
+	case ActionToggleCentroid:
+		return "ToggleCentroid"
+	case ActionFollowCentroid:
+		return "FollowCentroid"
+	case ActionSoftReset:
+		return "SoftReset"
+	case ActionCopy:
+		return "Copy"
+	case ActionToggleRendering:
+		return "ToggleRendering"
+	case ActionUndo:
+		return "Undo"
+	case ActionEdit:
+		return "Edit"
+	case ActionListScenarios:
+		return "ListScenarios"
+	default:
+		return "Unknown"
+	}
+}
+
+// KeyBinding is a key and the modifiers that have to be held with it.
+type KeyBinding struct {
+	Key ebiten.Key
+	// Control requires Ctrl (or Cmd) to be held. Bindings without it do not
+	// trigger while it is, so that e.g. Ctrl+C does not lock the camera.
+	Control bool
+	// Shift requires Shift to be held.
+	Shift bool
+}
+
+func (b KeyBinding) String() string {
+	name := b.Key.String()
+	if b.Shift {
+		name = "Shift+" + name
+	}
+	if b.Control {
+		name = "Ctrl+" + name
+	}
+	return name
+}
+
+// KeyBindings maps actions to the keys, with their modifiers, that trigger
+// them.
+type KeyBindings map[Action]KeyBinding
+
+func DefaultKeyBindings() KeyBindings {
+	return KeyBindings{
+		ActionTogglePlay:           {Key: ebiten.KeySpace},
+		ActionReset:                {Key: ebiten.KeyR, Shift: true},
+		ActionSwitchTheme:          {Key: ebiten.KeyT},
+		ActionSwitchRule:           {Key: ebiten.KeyM},
+		ActionSetGeneration:        {Key: ebiten.KeyG},
+		ActionStep:                 {Key: ebiten.KeyN},
+		ActionRandomize:            {Key: ebiten.KeyX},
+		ActionPreviewRule:          {Key: ebiten.KeyP},
+		ActionFastForward:          {Key: ebiten.KeyEqual},
+		ActionSlowForward:          {Key: ebiten.KeyMinus},
+		ActionToggleAgeHistogram:   {Key: ebiten.KeyL},
+		ActionToggleTurbo:          {Key: ebiten.KeyF5},
+		ActionToggleAutoPause:      {Key: ebiten.KeyY},
+		ActionSlowDown:             {Key: ebiten.KeyBracketLeft},
+		ActionSpeedUp:              {Key: ebiten.KeyBracketRight},
+		ActionPaste:                {Key: ebiten.KeyV, Control: true},
+		ActionTogglePatternedCells: {Key: ebiten.KeyH, Control: true},
+		ActionToggleVirginCells:    {Key: ebiten.KeyB, Control: true},
+		ActionToggleZoneHighlight:  {Key: ebiten.KeyZ, Control: true},
+		ActionColorComponents:      {Key: ebiten.KeyK},
+		ActionToggleZones:          {Key: ebiten.KeyO},
+		ActionPanUp:                {Key: ebiten.KeyW},
+		ActionPanDown:              {Key: ebiten.KeyS},
+		ActionPanLeft:              {Key: ebiten.KeyA},
+		ActionPanRight:             {Key: ebiten.KeyD},
+		ActionLockCamera:           {Key: ebiten.KeyC},
+		ActionToggleBigCounter:     {Key: ebiten.KeyI},
+		ActionCycleRenderMode:      {Key: ebiten.KeyF1},
+		ActionInvertTheme:          {Key: ebiten.KeyF2},
+		ActionStepToPeak:           {Key: ebiten.KeyPeriod},
+		ActionStepToTrough:         {Key: ebiten.KeyComma},
+		ActionVolumeUp:             {Key: ebiten.KeyUp, Control: true},
+		ActionVolumeDown:           {Key: ebiten.KeyDown, Control: true},
+		ActionToggleQuadrantStats:  {Key: ebiten.KeyQ},
+		ActionToggleCentroid:       {Key: ebiten.KeyF3},
+		ActionFollowCentroid:       {Key: ebiten.KeyF4},
+		ActionSoftReset:            {Key: ebiten.KeyR, Control: true},
+		ActionCopy:                 {Key: ebiten.KeyC, Control: true},
+		ActionToggleRendering:      {Key: ebiten.KeyE},
+		ActionUndo:                 {Key: ebiten.KeyU},
+		ActionListScenarios:        {Key: ebiten.KeyO, Control: true, Shift: true},
+	}
+}
+
+func (g *Game) actionJustPressed(a Action) bool {
+	if g.lockedActions[a] {
+		return false
+	}
+	b, ok := g.keyBindings[a]
+	return ok && inpututil.IsKeyJustPressed(b.Key) && b.Control == isControlPressed() && (!b.Shift || ebiten.IsKeyPressed(ebiten.KeyShift))
+}
+
+// keyName is the name of the key combination bound to a, for the HUD.
+func (g *Game) keyName(a Action) string {
+	b, ok := g.keyBindings[a]
+	if !ok {
+		return "?"
+	}
+	return b.String()
+}
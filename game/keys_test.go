@@ -0,0 +1,22 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestKeyBindingModifiers(t *testing.T) {
+	g := NewFromOptions(Options{CellSize: 10, KeyBindings: KeyBindings{ActionReset: {Key: ebiten.KeyF6}}})
+	t.Cleanup(g.Close)
+	for action, want := range map[Action]string{
+		ActionReset:         "F6",
+		ActionSoftReset:     "Ctrl+R",
+		ActionListScenarios: "Ctrl+Shift+O",
+		ActionTogglePlay:    "Space",
+	} {
+		if got := g.keyName(action); got != want {
+			t.Errorf("keyName(%s) = %q, want %q", action, got, want)
+		}
+	}
+}
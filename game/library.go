@@ -0,0 +1,26 @@
+package game
+
+import (
+	"archive/zip"
+	"io"
+)
+
+// ExportLibrary writes the built-in patterns to w as a zip archive with one
+// RLE file per pattern, named after the pattern.
+func ExportLibrary(w io.Writer) error {
+	archive := zip.NewWriter(w)
+	for _, name := range BuiltinPatternNames() {
+		p, err := BuiltinPattern(name)
+		if err != nil {
+			return err
+		}
+		entry, err := archive.Create(name + ".rle")
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write(EncodeRLE(p)); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
@@ -0,0 +1,34 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// Locale translates HUD text. Translations is keyed by the English text, so
+// a JSON locale file is a single object such as {"Theme": "Tema"}.
+type Locale struct {
+	Translations map[string]string
+}
+
+func LoadLocale(path string) (*Locale, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var translations map[string]string
+	if err := json.Unmarshal(data, &translations); err != nil {
+		return nil, err
+	}
+	return &Locale{Translations: translations}, nil
+}
+
+// t looks up the translation of key, falling back to the key itself.
+func (g *Game) t(key string) string {
+	if g.locale != nil {
+		if text, ok := g.locale.Translations[key]; ok {
+			return text
+		}
+	}
+	return key
+}
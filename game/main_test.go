@@ -0,0 +1,35 @@
+package game
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TestMain runs the tests from inside the game loop, where images can be
+// read back, so they need a display (e.g. xvfb-run on a headless machine).
+func TestMain(m *testing.M) {
+	code := 0
+	loop := &testLoop{run: func() { code = m.Run() }}
+	if err := ebiten.RunGame(loop); err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(code)
+}
+
+type testLoop struct {
+	run func()
+}
+
+func (l *testLoop) Update() error {
+	l.run()
+	return ebiten.Termination
+}
+
+func (l *testLoop) Draw(*ebiten.Image) {}
+
+func (l *testLoop) Layout(int, int) (int, int) {
+	return 1, 1
+}
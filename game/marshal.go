@@ -0,0 +1,84 @@
+package game
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// gridHeaderBytes is the size of the header MarshalGrid starts with: the
+// number of columns and of rows as big-endian uint16s.
+const gridHeaderBytes = 4
+
+// marshaledGridBytes is the size of a grid of the given size packed by
+// MarshalGrid.
+func marshaledGridBytes(columns, rows int) int {
+	return gridHeaderBytes + (columns*rows+7)/8
+}
+
+// MarshalGrid bit-packs the active columns and rows in row-major order, one
+// bit per cell with the first cell in the most significant bit, after a
+// header holding their number, e.g. for sending the grid to another
+// process. A 64x48 grid takes 388 bytes.
+func (g *Game) MarshalGrid() []byte {
+	data := make([]byte, marshaledGridBytes(g.columns, g.rows))
+	binary.BigEndian.PutUint16(data, uint16(g.columns))
+	binary.BigEndian.PutUint16(data[2:], uint16(g.rows))
+	cells := data[gridHeaderBytes:]
+	for j := 0; j < g.rows; j++ {
+		for i := 0; i < g.columns; i++ {
+			if g.currentGrid[i][j] {
+				bit := j*g.columns + i
+				cells[bit/8] |= 0x80 >> (bit % 8)
+			}
+		}
+	}
+	return data
+}
+
+// UnmarshalGrid replaces the grid with one packed by MarshalGrid, which must
+// have as many columns and rows as the grid.
+func (g *Game) UnmarshalGrid(data []byte) error {
+	if err := g.checkMarshaledGrid(data); err != nil {
+		return err
+	}
+	for j := 0; j < g.rows; j++ {
+		for i := 0; i < g.columns; i++ {
+			g.currentGrid[i][j] = marshaledCell(data, g.columns, i, j)
+			g.ages[i][j] = 0
+		}
+	}
+	return nil
+}
+
+// marshaledGridSize returns the number of columns and rows of a grid packed
+// by MarshalGrid, checking that data holds all of its cells.
+func marshaledGridSize(data []byte) (int, int, error) {
+	if len(data) < gridHeaderBytes {
+		return 0, 0, fmt.Errorf("marshaled grid must be at least %d bytes, got %d", gridHeaderBytes, len(data))
+	}
+	columns, rows := int(binary.BigEndian.Uint16(data)), int(binary.BigEndian.Uint16(data[2:]))
+	if want := marshaledGridBytes(columns, rows); len(data) != want {
+		return 0, 0, fmt.Errorf("marshaled %dx%d grid must be %d bytes, got %d", columns, rows, want, len(data))
+	}
+	return columns, rows, nil
+}
+
+// checkMarshaledGrid checks that data is a grid packed by MarshalGrid with
+// as many columns and rows as the game's.
+func (g *Game) checkMarshaledGrid(data []byte) error {
+	columns, rows, err := marshaledGridSize(data)
+	if err != nil {
+		return err
+	}
+	if columns != g.columns || rows != g.rows {
+		return fmt.Errorf("marshaled grid is %dx%d but the grid is %dx%d", columns, rows, g.columns, g.rows)
+	}
+	return nil
+}
+
+// marshaledCell reports whether the cell at (x, y) of a grid with the given
+// number of columns packed by MarshalGrid is alive.
+func marshaledCell(data []byte, columns, x, y int) bool {
+	bit := y*columns + x
+	return data[gridHeaderBytes+bit/8]&(0x80>>(bit%8)) != 0
+}
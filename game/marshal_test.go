@@ -0,0 +1,42 @@
+package game_test
+
+import (
+	"gameoflife/game"
+	"testing"
+)
+
+func TestMarshalGridRoundTrip(t *testing.T) {
+	g := newGame(t, game.Options{Seed: 1})
+	g.Randomize(game.SymmetryNone)
+	want := *g.CurrentGrid()
+	data := g.MarshalGrid()
+	// A 64x48 grid packs into a 4 byte header and 384 bytes of cells.
+	if len(data) != 388 {
+		t.Errorf("MarshalGrid returned %d bytes, want 388", len(data))
+	}
+	if err := g.UnmarshalGrid(data); err != nil {
+		t.Fatalf("UnmarshalGrid: %v", err)
+	}
+	if *g.CurrentGrid() != want {
+		t.Error("UnmarshalGrid(MarshalGrid()) changed the grid")
+	}
+
+	other := newGame(t, game.Options{})
+	if err := other.UnmarshalGrid(data); err != nil {
+		t.Fatalf("UnmarshalGrid into another game: %v", err)
+	}
+	if *other.CurrentGrid() != want {
+		t.Error("UnmarshalGrid into another game does not reproduce the grid")
+	}
+}
+
+func TestUnmarshalGridRejectsOtherSizes(t *testing.T) {
+	data := newGame(t, game.Options{CellSize: 20}).MarshalGrid()
+	g := newGame(t, game.Options{})
+	if err := g.UnmarshalGrid(data); err == nil {
+		t.Error("UnmarshalGrid accepted a 32x24 grid on a 64x48 one")
+	}
+	if err := g.UnmarshalGrid(g.MarshalGrid()[:100]); err == nil {
+		t.Error("UnmarshalGrid accepted a truncated grid")
+	}
+}
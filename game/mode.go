@@ -0,0 +1,53 @@
+package game
+
+type Mode int
+
+func (m Mode) String() string {
+	switch m {
+	case ModeLife:
+		return "Life"
+	case ModeWireWorld:
+		return "Wire World"
+	case ModeElementary1D:
+		return "Elementary 1D"
+	case ModeBriansBrain:
+		return "Brian's Brain"
+	default:
+		return "Unknown"
+	}
+}
+
+func (m Mode) MarshalText() ([]byte, error) {
+	return enumText(m, modeCount)
+}
+
+func (m *Mode) UnmarshalText(text []byte) error {
+	return parseEnumText(m, text, modeCount)
+}
+
+const (
+	ModeLife Mode = iota
+	ModeWireWorld
+	ModeElementary1D
+	ModeBriansBrain
+	// modeCount is the number of modes; new modes go before it.
+	modeCount
+)
+
+// SetMode switches the simulation mode, clearing the grid. Entering Wire
+// World selects its theme; entering Elementary 1D seeds a single live cell in
+// the middle of the first row.
+func (g *Game) SetMode(mode Mode) {
+	g.mode = mode
+	g.hardReset()
+	switch mode {
+	case ModeWireWorld:
+		g.selectedThemeID = WireWorld
+	case ModeElementary1D:
+		g.elementarySeed[g.columns/2] = true
+		g.restartElementary()
+	}
+	if mode != ModeWireWorld && g.selectedThemeID == WireWorld {
+		g.selectedThemeID = Dark
+	}
+}
@@ -0,0 +1,70 @@
+package game
+
+import "fmt"
+
+// OversizePolicy is what loaders do with patterns larger than the grid.
+type OversizePolicy int
+
+const (
+	// OversizeError rejects the pattern.
+	OversizeError OversizePolicy = iota
+	// OversizeClip keeps the middle of the pattern that fits on the grid.
+	OversizeClip
+	// OversizeShrink lowers the cell size until the pattern fits, down to
+	// Options.MinCellSize, and rejects the pattern if it still does not fit.
+	OversizeShrink
+)
+
+func (p OversizePolicy) String() string {
+	switch p {
+	case OversizeError:
+		return "Error"
+	case OversizeClip:
+		return "Clip"
+	case OversizeShrink:
+		return "Shrink"
+	default:
+		return "Unknown"
+	}
+}
+
+// fitPattern applies the oversize policy to p, returning the pattern to
+// place on the grid.
+func (g *Game) fitPattern(p *Pattern) (*Pattern, error) {
+	width, height := p.Width(), p.Height()
+	if width <= g.columns && height <= g.rows {
+		return p, nil
+	}
+	tooLarge := fmt.Errorf("pattern is %dx%d but the grid is only %dx%d", width, height, g.columns, g.rows)
+	switch g.oversizePolicy {
+	case OversizeClip:
+		return g.clipPattern(p), nil
+	case OversizeShrink:
+		size := min(g.screenWidth/width, g.screenHeight/height)
+		if size < g.minCellSize || width > maxColumns || height > maxRows {
+			return nil, tooLarge
+		}
+		if err := g.SetCellSize(size); err != nil {
+			return nil, fmt.Errorf("%w: %v", tooLarge, err)
+		}
+		return p, nil
+	default:
+		return nil, tooLarge
+	}
+}
+
+// clipPattern crops p to its middle columns by rows cells. On hex grids the
+// crop starts on an even row so that the pattern keeps its shape.
+func (g *Game) clipPattern(p *Pattern) *Pattern {
+	width, height := min(p.Width(), g.columns), min(p.Height(), g.rows)
+	left, top := (p.Width()-width)/2, g.alignRow((p.Height()-height)/2)
+	clipped := &Pattern{Name: p.Name, Rule: p.Rule, Cells: make([][]uint8, height)}
+	for y := range height {
+		row := p.Cells[top+y]
+		clipped.Cells[y] = make([]uint8, width)
+		if left < len(row) {
+			copy(clipped.Cells[y], row[left:])
+		}
+	}
+	return clipped
+}
@@ -0,0 +1,98 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"math"
+)
+
+// startPainting begins a drag from the cell that was just toggled, painting
+// every cell the drag passes over with that cell's new state.
+func (g *Game) startPainting(cellX, cellY int) {
+	if g.mode != ModeLife || g.gridTopology != TopologySquare {
+		return
+	}
+	g.painting, g.paintAlive = true, g.currentGrid[cellX][cellY]
+	g.lastPaintX, g.lastPaintY = cellX, cellY
+}
+
+// paintDrag paints the cells between the previous and the current cursor
+// cell. On wrapping axes the stroke takes the short way around, so one that
+// leaves an edge continues on the opposite one.
+func (g *Game) paintDrag() {
+	if !g.painting {
+		return
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		g.painting = false
+		return
+	}
+	cellX, cellY := g.unboundedCellAt(ebiten.CursorPosition())
+	dx, dy := cellX-g.lastPaintX, cellY-g.lastPaintY
+	if g.wrapX {
+		dx = shortestWrap(dx, g.columns)
+	}
+	if g.wrapY {
+		dy = shortestWrap(dy, g.rows)
+	}
+	if dx == 0 && dy == 0 {
+		return
+	}
+	steps := max(abs(dx), abs(dy))
+	for step := 1; step <= steps; step++ {
+		x := g.lastPaintX + int(math.Round(float64(dx*step)/float64(steps)))
+		y := g.lastPaintY + int(math.Round(float64(dy*step)/float64(steps)))
+		if x, y, ok := g.wrapCell(x, y); ok {
+			g.paintCell(x, y)
+		}
+	}
+	g.lastPaintX, g.lastPaintY, _ = g.wrapCell(g.lastPaintX+dx, g.lastPaintY+dy)
+}
+
+func (g *Game) paintCell(x, y int) {
+	if g.currentGrid[x][y] == g.paintAlive {
+		return
+	}
+	g.recordEdit()
+	g.currentGrid[x][y] = g.paintAlive
+	g.ages[x][y] = 0
+	g.everAlive[x][y] = true
+	g.everDead[x][y] = true
+}
+
+// unboundedCellAt is the cell under a screen position, which may be off the
+// grid.
+func (g *Game) unboundedCellAt(x, y int) (int, int) {
+	size := g.viewCellSize()
+	return int(math.Floor((float64(x) + g.offsetX) / size)), int(math.Floor((float64(y) + g.offsetY) / size))
+}
+
+// wrapCell wraps a cell onto the grid along the wrapping axes and reports
+// whether it is then on the grid.
+func (g *Game) wrapCell(x, y int) (int, int, bool) {
+	if g.wrapX {
+		x = (x%g.columns + g.columns) % g.columns
+	}
+	if g.wrapY {
+		y = (y%g.rows + g.rows) % g.rows
+	}
+	return x, y, x >= 0 && x < g.columns && y >= 0 && y < g.rows
+}
+
+// shortestWrap picks the shorter of the distances d and d±size around a
+// wrapping axis of size cells.
+func shortestWrap(d, size int) int {
+	d %= size
+	if d > size/2 {
+		d -= size
+	} else if d < -size/2 {
+		d += size
+	}
+	return d
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
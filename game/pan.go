@@ -0,0 +1,188 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"image"
+	"image/color"
+	"math"
+)
+
+// Pan scrolls the view by (dx, dy) screen pixels. Along axes the topology
+// wraps the view wraps around too, matching the simulation; along bounded
+// axes it stops at the edges of the grid.
+func (g *Game) Pan(dx, dy float64) {
+	width, height := g.worldSize()
+	g.offsetX = panAxis(g.offsetX+dx, width, float64(g.screenWidth), g.wrapX)
+	g.offsetY = panAxis(g.offsetY+dy, height, float64(g.screenHeight), g.wrapY)
+}
+
+// panKeys pans the paused view by a cell per press of the pan keys, or by
+// panShiftMultiplier cells while Shift is held.
+func (g *Game) panKeys() {
+	step := g.viewCellSize()
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		step *= panShiftMultiplier
+	}
+	for action, direction := range map[Action][2]float64{
+		ActionPanUp:    {0, -1},
+		ActionPanDown:  {0, 1},
+		ActionPanLeft:  {-1, 0},
+		ActionPanRight: {1, 0},
+	} {
+		if g.actionJustPressed(action) {
+			g.Pan(direction[0]*step, direction[1]*step)
+		}
+	}
+}
+
+const panShiftMultiplier = 5
+
+// panDrag pans the view along with the cursor while the middle mouse button
+// is held.
+func (g *Game) panDrag() {
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle) {
+		g.panDragging = false
+		return
+	}
+	x, y := ebiten.CursorPosition()
+	if g.panDragging {
+		g.Pan(float64(g.panDragX-x), float64(g.panDragY-y))
+	}
+	g.panDragging, g.panDragX, g.panDragY = true, x, y
+}
+
+// centerOnLiveCells pans the view so that the centroid of the live cells is
+// in the middle of the screen.
+func (g *Game) centerOnLiveCells() {
+	centroidX, centroidY := g.Centroid()
+	if math.IsNaN(centroidX) {
+		return
+	}
+	size := g.viewCellSize()
+	x := (centroidX+0.5)*size - float64(g.screenWidth)/2
+	y := (centroidY+0.5)*size - float64(g.screenHeight)/2
+	g.Pan(x-g.offsetX, y-g.offsetY)
+}
+
+// followSmoothing is the fraction of the way to the centroid the view moves
+// each frame while following it, which hides the jitter of oscillating
+// cells.
+const followSmoothing = 0.1
+
+// followCentroid eases the view toward centering the centroid, taking the
+// short way around axes that wrap.
+func (g *Game) followCentroid() {
+	if !g.centroid.ok {
+		return
+	}
+	size := g.viewCellSize()
+	width, height := g.worldSize()
+	dx := (g.centroid.x+0.5)*size - float64(g.screenWidth)/2 - g.offsetX
+	dy := (g.centroid.y+0.5)*size - float64(g.screenHeight)/2 - g.offsetY
+	if g.wrapX {
+		dx = math.Remainder(dx, width)
+	}
+	if g.wrapY {
+		dy = math.Remainder(dy, height)
+	}
+	g.Pan(dx*followSmoothing, dy*followSmoothing)
+}
+
+func panAxis(offset, world, view float64, wrap bool) float64 {
+	if wrap {
+		return math.Mod(math.Mod(offset, world)+world, world)
+	}
+	return max(min(offset, world-view), 0)
+}
+
+// worldSize is the size of the zoomed grid in screen pixels.
+func (g *Game) worldSize() (float64, float64) {
+	size := g.viewCellSize()
+	return float64(g.columns) * size, float64(g.rows) * size
+}
+
+// viewCellSize is the size cells are shown at after zooming.
+func (g *Game) viewCellSize() float64 {
+	return float64(g.cellSize) * g.zoom
+}
+
+// transformed reports whether the view is panned or zoomed, so that the
+// scene can no longer be drawn straight to the screen.
+func (g *Game) transformed() bool {
+	return g.offsetX != 0 || g.offsetY != 0 || g.zoom != 1
+}
+
+// worldPosition maps a screen position to a position on the unzoomed grid in
+// pixels.
+func (g *Game) worldPosition(x, y int) (int, int) {
+	width, height := g.worldSize()
+	wx, wy := float64(x)+g.offsetX, float64(y)+g.offsetY
+	if g.wrapX {
+		wx = math.Mod(wx, width)
+	}
+	if g.wrapY {
+		wy = math.Mod(wy, height)
+	}
+	return int(math.Floor(wx / g.zoom)), int(math.Floor(wy / g.zoom))
+}
+
+// maxZoomedCellSize is the largest size the mouse wheel zooms cells to.
+const maxZoomedCellSize = 40
+
+// zoomWheel zooms the view in or out by a pixel of cell size per step of the
+// mouse wheel, keeping the point under the cursor in place.
+func (g *Game) zoomWheel() {
+	_, wheel := ebiten.Wheel()
+	if wheel == 0 {
+		return
+	}
+	size := math.Round(g.viewCellSize())
+	if wheel > 0 {
+		size++
+	} else {
+		size--
+	}
+	size = max(min(size, maxZoomedCellSize), float64(g.minCellSize))
+	zoom := size / float64(g.cellSize)
+	x, y := ebiten.CursorPosition()
+	cursorX, cursorY := float64(x), float64(y)
+	// The unzoomed grid position under the cursor has to stay there.
+	worldX, worldY := (cursorX+g.offsetX)/g.zoom, (cursorY+g.offsetY)/g.zoom
+	g.zoom = zoom
+	g.offsetX, g.offsetY = 0, 0
+	g.Pan(worldX*zoom-cursorX, worldY*zoom-cursorY)
+}
+
+// drawView draws the scene image, drawn at the given scale of the screen, to
+// the screen at the current pan offset. Wrapping axes repeat the grid so
+// that the view is always covered.
+func (g *Game) drawView(screen, scene *ebiten.Image, scale float64) {
+	if !g.transformed() {
+		op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+		op.GeoM.Scale(1/scale, 1/scale)
+		screen.DrawImage(scene, op)
+		return
+	}
+	if g.renderMode == RenderMinimal {
+		screen.Fill(color.Black)
+	} else {
+		screen.Fill(g.theme().BackgroundColor)
+	}
+	width, height := g.worldSize()
+	sceneWidth, sceneHeight := width/g.zoom*scale, height/g.zoom*scale
+	worldImage := scene.SubImage(scene.Bounds().Intersect(image.Rect(0, 0, int(math.Ceil(sceneWidth)), int(math.Ceil(sceneHeight))))).(*ebiten.Image)
+	for tx := -g.offsetX; tx < float64(g.screenWidth); tx += width {
+		for ty := -g.offsetY; ty < float64(g.screenHeight); ty += height {
+			op := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+			op.GeoM.Scale(g.zoom/scale, g.zoom/scale)
+			op.GeoM.Translate(tx, ty)
+			screen.DrawImage(worldImage, op)
+			if !g.wrapY {
+				break
+			}
+		}
+		if !g.wrapX {
+			break
+		}
+	}
+}
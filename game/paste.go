@@ -0,0 +1,131 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type clipboardResult struct {
+	text string
+	err  error
+}
+
+// requestPaste reads the clipboard for a paste at the given cell. The result
+// arrives asynchronously and is applied by applyPendingPaste.
+func (g *Game) requestPaste(cellX, cellY int) {
+	g.pasteX, g.pasteY = cellX, cellY
+	readClipboard(func(text string, err error) {
+		select {
+		case g.pastes <- clipboardResult{text: text, err: err}:
+		default:
+		}
+	})
+}
+
+func (g *Game) applyPendingPaste() {
+	select {
+	case result := <-g.pastes:
+		if result.err != nil {
+			log.Printf("could not read the clipboard: %v", result.err)
+			return
+		}
+		p, err := parsePastedPattern(result.text)
+		if err != nil {
+			log.Printf("could not paste the clipboard: %v", err)
+			return
+		}
+		g.state = Paused
+		g.stampPattern(p, g.pasteX, g.pasteY)
+	default:
+	}
+}
+
+// parsePastedPattern reads text as RLE, or failing that as Plaintext.
+func parsePastedPattern(text string) (*Pattern, error) {
+	p, rleErr := ParseRLE([]byte(text))
+	if rleErr == nil && p.Width() > 0 {
+		return p, nil
+	}
+	if rleErr == nil {
+		rleErr = errors.New("no cells")
+	}
+	p, plaintextErr := ParsePlaintext([]byte(text))
+	if plaintextErr == nil && p.Width() > 0 {
+		return p, nil
+	}
+	if plaintextErr == nil {
+		plaintextErr = errors.New("no cells")
+	}
+	return nil, fmt.Errorf("not a recognized pattern: as RLE: %v; as Plaintext: %v", rleErr, plaintextErr)
+}
+
+// CopyToClipboard puts the smallest rectangle holding every live cell, or
+// every non-empty Wire World cell, on the system clipboard as RLE.
+func (g *Game) CopyToClipboard() error {
+	p, err := g.gridPattern()
+	if err != nil {
+		return err
+	}
+	return writeClipboard(string(EncodeRLE(p)))
+}
+
+func (g *Game) gridPattern() (*Pattern, error) {
+	if g.mode == ModeElementary1D {
+		return nil, fmt.Errorf("patterns cannot be copied in %s mode", g.mode)
+	}
+	state := func(x, y int) uint8 {
+		if g.multiState() {
+			return g.states[x][y]
+		}
+		return uint8(b2i(g.currentGrid[x][y]))
+	}
+	minX, minY, maxX, maxY := g.columns, g.rows, -1, -1
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if state(i, j) != 0 {
+				minX, maxX = min(minX, i), max(maxX, i)
+				minY, maxY = min(minY, j), max(maxY, j)
+			}
+		}
+	}
+	if maxX < 0 {
+		return nil, errors.New("the grid is empty")
+	}
+	p := &Pattern{Rule: g.ruleString(), Cells: make([][]uint8, maxY-minY+1)}
+	for y := range p.Cells {
+		p.Cells[y] = make([]uint8, maxX-minX+1)
+		for x := range p.Cells[y] {
+			p.Cells[y][x] = state(minX+x, minY+y)
+		}
+	}
+	return p, nil
+}
+
+// stampPattern places p with its top-left corner at the given cell without
+// clearing the grid. The origin is moved so that the pattern stays on the
+// grid; cells of patterns larger than the grid are dropped.
+func (g *Game) stampPattern(p *Pattern, originX, originY int) {
+	originX = max(min(originX, g.columns-p.Width()), 0)
+	originY = g.alignRow(max(min(originY, g.rows-p.Height()), 0))
+	g.recordEdit()
+	for y, row := range p.Cells {
+		for x, state := range row {
+			cellX, cellY := originX+x, originY+y
+			if cellX >= g.columns || cellY >= g.rows || state == 0 {
+				continue
+			}
+			if g.multiState() {
+				g.states[cellX][cellY] = state % g.stateCount()
+			} else {
+				g.currentGrid[cellX][cellY] = true
+			}
+		}
+	}
+}
+
+func isControlPressed() bool {
+	return ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+}
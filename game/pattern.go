@@ -0,0 +1,175 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+)
+
+//go:embed patterns
+var builtinPatterns embed.FS
+
+// Pattern is a rectangular block of cell states indexed as Cells[y][x]. In
+// Life mode any non-zero state is a live cell.
+type Pattern struct {
+	Name  string
+	Rule  string
+	Cells [][]uint8
+}
+
+func (p *Pattern) Width() int {
+	width := 0
+	for _, row := range p.Cells {
+		width = max(width, len(row))
+	}
+	return width
+}
+
+func (p *Pattern) Height() int {
+	return len(p.Cells)
+}
+
+// BuiltinPatternNames lists the names BuiltinPattern accepts.
+func BuiltinPatternNames() []string {
+	entries, _ := builtinPatterns.ReadDir("patterns")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".cells"); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func BuiltinPattern(name string) (*Pattern, error) {
+	data, err := builtinPatterns.ReadFile("patterns/" + name + ".cells")
+	if err != nil {
+		return nil, fmt.Errorf("unknown pattern %q", name)
+	}
+	return ParsePlaintext(data)
+}
+
+// ParsePlaintext decodes the Plaintext (.cells) format. Besides '.' and 'O'
+// it accepts the digits 0-3 so multi-state Wire World circuits can be stored,
+// and a "!Rule:" comment line naming the rule the pattern is meant for.
+func ParsePlaintext(data []byte) (*Pattern, error) {
+	p := &Pattern{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		if strings.HasPrefix(text, "!") {
+			if name, ok := strings.CutPrefix(text, "!Name:"); ok {
+				p.Name = strings.TrimSpace(name)
+			} else if rule, ok := strings.CutPrefix(text, "!Rule:"); ok {
+				p.Rule = strings.TrimSpace(rule)
+			}
+			continue
+		}
+		row := make([]uint8, len(text))
+		for x, c := range text {
+			switch {
+			case c == '.':
+				row[x] = 0
+			case c == 'O' || c == '*':
+				row[x] = 1
+			case c >= '0' && c <= '3':
+				row[x] = uint8(c - '0')
+			default:
+				return nil, fmt.Errorf("line %d: unexpected character %q", line, c)
+			}
+		}
+		p.Cells = append(p.Cells, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (g *Game) LoadPlaintext(data []byte, opts LoadOptions) error {
+	p, err := ParsePlaintext(data)
+	if err != nil {
+		return err
+	}
+	return g.LoadPattern(p, opts)
+}
+
+// Origin is where loaders place a pattern on the grid.
+type Origin int
+
+const (
+	// OriginCenter centers the pattern on the grid.
+	OriginCenter Origin = iota
+	// OriginTopLeft puts the pattern in the top-left corner of the grid.
+	OriginTopLeft
+	// OriginOffset puts the top-left corner of the pattern at
+	// LoadOptions.X, LoadOptions.Y.
+	OriginOffset
+)
+
+// LoadOptions control how the pattern loaders place patterns.
+type LoadOptions struct {
+	Origin Origin
+	X, Y   int
+	// AdoptRule switches a Life game to the pattern's rule, when it has
+	// one, e.g. from the rule field of an RLE header.
+	AdoptRule bool
+}
+
+// patternRule parses the rule a pattern was written for. A bounded grid
+// suffix, as in "B3/S23:T100,100", is ignored: the pattern is loaded into
+// the game's own grid.
+func (g *Game) patternRule(s string) (Rule, error) {
+	s, _, _ = strings.Cut(s, ":")
+	rule, err := ParseRule(s)
+	if err != nil {
+		return Rule{}, fmt.Errorf("pattern has an invalid rule: %w", err)
+	}
+	if err := g.gridTopology.checkRule(rule); err != nil {
+		return Rule{}, fmt.Errorf("pattern rule cannot be used on this grid: %w", err)
+	}
+	return rule, nil
+}
+
+// LoadPattern clears the grid and places p according to opts. Patterns
+// larger than the grid are handled according to Options.OnOversizePattern.
+func (g *Game) LoadPattern(p *Pattern, opts LoadOptions) error {
+	p, err := g.fitPattern(p)
+	if err != nil {
+		return err
+	}
+	width, height := p.Width(), p.Height()
+	var originX, originY int
+	switch opts.Origin {
+	case OriginTopLeft:
+	case OriginOffset:
+		originX, originY = opts.X, g.alignRow(opts.Y)
+		if originX < 0 || originY < 0 || originX+width > g.columns || originY+height > g.rows {
+			return fmt.Errorf("%dx%d pattern at (%d, %d) does not fit on the %dx%d grid", width, height, originX, originY, g.columns, g.rows)
+		}
+	default:
+		originX, originY = (g.columns-width)/2, g.alignRow((g.rows-height)/2)
+	}
+	rule, adopt := g.rule, opts.AdoptRule && p.Rule != "" && g.mode == ModeLife
+	if adopt {
+		if rule, err = g.patternRule(p.Rule); err != nil {
+			return err
+		}
+	}
+	g.hardReset()
+	if adopt {
+		g.setRule(rule)
+	}
+	for y, row := range p.Cells {
+		for x, state := range row {
+			if g.multiState() {
+				g.states[originX+x][originY+y] = state % g.stateCount()
+			} else {
+				g.currentGrid[originX+x][originY+y] = state != 0
+			}
+		}
+	}
+	return nil
+}
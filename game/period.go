@@ -0,0 +1,85 @@
+package game
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// defaultDetectPeriodUpTo is the longest period detected unless
+// Options.DetectPeriodUpTo says otherwise. It covers the common oscillators
+// up to the pentadecathlon.
+const defaultDetectPeriodUpTo = 15
+
+// periodDetector spots still lifes and oscillators by remembering the hashes
+// of the last maxPeriod generations and looking for the current one among
+// them.
+type periodDetector struct {
+	hashes []uint64
+	size   int
+	next   int
+	// generation is the generation the newest hash was taken at.
+	generation int
+	// period is the detected period, or 0 when the grid is not repeating.
+	period int
+}
+
+func newPeriodDetector(maxPeriod int) periodDetector {
+	return periodDetector{hashes: make([]uint64, maxPeriod)}
+}
+
+func (d *periodDetector) add(generation int, hash uint64) {
+	if d.size > 0 && generation != d.generation+1 {
+		d.size, d.next = 0, 0
+	}
+	d.period = 0
+	for lag := 1; lag <= d.size; lag++ {
+		if d.hashes[(d.next-lag+len(d.hashes))%len(d.hashes)] == hash {
+			d.period = lag
+			break
+		}
+	}
+	d.hashes[d.next] = hash
+	d.next = (d.next + 1) % len(d.hashes)
+	d.size = min(d.size+1, len(d.hashes))
+	d.generation = generation
+}
+
+// gridHash hashes the active part of the grid, including the Wire World
+// states.
+func (g *Game) gridHash() uint64 {
+	h := fnv.New64a()
+	column := make([]byte, g.rows)
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			column[j] = byte(b2i(g.currentGrid[i][j])) | g.states[i][j]<<1
+		}
+		h.Write(column)
+	}
+	return h.Sum64()
+}
+
+// detectPeriod checks the current generation against the recent ones and
+// pauses the game when it starts repeating, if auto-pause is enabled.
+func (g *Game) detectPeriod() {
+	if g.mode == ModeElementary1D {
+		return
+	}
+	wasRepeating := g.periods.period != 0
+	g.periods.add(g.generation, g.gridHash())
+	if g.autoPause && !wasRepeating && g.periods.period != 0 {
+		g.state = Paused
+	}
+}
+
+func (g *Game) periodDescription() string {
+	switch {
+	case g.periods.period == 0 || g.periods.generation != g.generation:
+		return ""
+	case g.populations.latest() == 0:
+		return g.t("extinct")
+	case g.periods.period == 1:
+		return g.t("still life")
+	default:
+		return fmt.Sprintf(g.t("oscillator with period %d"), g.periods.period)
+	}
+}
@@ -0,0 +1,44 @@
+package game
+
+import "sync"
+
+// CycleBand is a range of rows [StartRow, EndRow) computed by one worker.
+type CycleBand struct {
+	StartRow, EndRow int
+}
+
+// cyclePool is a fixed set of goroutines started with the game that compute
+// bands of the next generation, so cycle does not pay goroutine start-up costs
+// every generation. Bands are pulled from a shared channel, so idle workers
+// pick up the remaining work.
+type cyclePool struct {
+	bands   chan CycleBand
+	pending sync.WaitGroup
+}
+
+func newCyclePool(workers int, work func(band CycleBand)) *cyclePool {
+	p := &cyclePool{bands: make(chan CycleBand, workers)}
+	for range workers {
+		go func() {
+			for band := range p.bands {
+				work(band)
+				p.pending.Done()
+			}
+		}()
+	}
+	return p
+}
+
+// run splits rows into count bands and blocks until all of them are done.
+func (p *cyclePool) run(rows, count int) {
+	size := max((rows+count-1)/count, 1)
+	for start := 0; start < rows; start += size {
+		p.pending.Add(1)
+		p.bands <- CycleBand{StartRow: start, EndRow: min(start+size, rows)}
+	}
+	p.pending.Wait()
+}
+
+func (p *cyclePool) close() {
+	close(p.bands)
+}
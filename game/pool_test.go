@@ -0,0 +1,46 @@
+package game
+
+import (
+	"sync"
+	"testing"
+)
+
+// poolBenchmarkGenerations is how many generations each benchmark iteration
+// computes on the 128x96 grid of 5 pixel cells.
+const poolBenchmarkGenerations = 1000
+
+// spawnCycleLife computes a generation the way cycleLife did before the
+// pool, starting one goroutine per band.
+func spawnCycleLife(g *Game, count int) {
+	var wg sync.WaitGroup
+	size := max((g.rows+count-1)/count, 1)
+	for start := 0; start < g.rows; start += size {
+		wg.Add(1)
+		go func(band CycleBand) {
+			defer wg.Done()
+			g.cycleLifeBand(band)
+		}(CycleBand{StartRow: start, EndRow: min(start+size, g.rows)})
+	}
+	wg.Wait()
+	g.currentGrid = g.nextGrid()
+}
+
+func BenchmarkCycleLifePool(b *testing.B) {
+	g := newRandomGame(b, Options{CellSize: 5, Topology: TopologyToroidal, Seed: 1})
+	b.ResetTimer()
+	for range b.N {
+		for range poolBenchmarkGenerations {
+			g.cycleLife()
+		}
+	}
+}
+
+func BenchmarkCycleLifeSpawn(b *testing.B) {
+	g := newRandomGame(b, Options{CellSize: 5, Topology: TopologyToroidal, Seed: 1})
+	b.ResetTimer()
+	for range b.N {
+		for range poolBenchmarkGenerations {
+			spawnCycleLife(g, 4*g.workers)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"image/color"
+)
+
+// previewColor is the translucent color of the ghost overlay.
+var previewColor = color.NRGBA{R: 0, G: 200, B: 255, A: 96}
+
+// PreviewNext computes the generation that would follow the current one
+// under r without applying it.
+func (g *Game) PreviewNext(r Rule) *Grid {
+	next := &Grid{}
+	g.previewInto(next, r)
+	return next
+}
+
+// previewInto is PreviewNext writing into next, which drawPreview reuses
+// every frame. Only the active cells are written.
+func (g *Game) previewInto(next *Grid, r Rule) {
+	table := precomputeRuleTable(r)
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			next[i][j] = g.nextLifeState(&table, i, j)
+		}
+	}
+}
+
+// previewedRule is the rule the ghost overlay shows, the one the rule hotkey
+// would switch to next. Hex grids have no presets to switch to, so they
+// preview their own rule.
+func (g *Game) previewedRule() Rule {
+	if g.gridTopology == TopologyHex {
+		return g.rule
+	}
+	return RulePresets[(g.rulePreset+1)%len(RulePresets)]
+}
+
+// drawPreview overlays the next generation under the previewed rule while
+// the game is paused.
+func (g *Game) drawPreview(dst *ebiten.Image) {
+	if !g.previewing || g.state != Paused || g.mode != ModeLife {
+		return
+	}
+	next := &g.previewGrid
+	g.previewInto(next, g.previewedRule())
+	size := g.drawCellSize()
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if !next[i][j] {
+				continue
+			}
+			if g.gridTopology == TopologyHex {
+				x, y := g.hexOrigin(i, j)
+				g.drawSprite(dst, x, y, spriteKey{hex: true, color: previewColor})
+			} else {
+				g.drawSprite(dst, float32(i)*size, float32(j)*size, spriteKey{shape: g.theme().CellShape, color: previewColor})
+			}
+		}
+	}
+}
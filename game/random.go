@@ -0,0 +1,94 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// RandomMode is how the randomize key fills the grid.
+type RandomMode int
+
+const (
+	// RandomBiased uses RandomInteresting.
+	RandomBiased RandomMode = iota
+	// RandomUniform uses Randomize.
+	RandomUniform
+	randomModeCount
+)
+
+func (m RandomMode) String() string {
+	switch m {
+	case RandomBiased:
+		return "Biased"
+	case RandomUniform:
+		return "Uniform"
+	default:
+		return "Unknown"
+	}
+}
+
+func (m RandomMode) MarshalText() ([]byte, error) {
+	return enumText(m, randomModeCount)
+}
+
+func (m *RandomMode) UnmarshalText(text []byte) error {
+	return parseEnumText(m, text, randomModeCount)
+}
+
+// methuselahs are small seeds that take hundreds of generations to settle,
+// as cell offsets: the R-pentomino, the pi-heptomino, the B-heptomino and
+// the acorn.
+var methuselahs = [][][2]int{
+	{{1, 0}, {2, 0}, {0, 1}, {1, 1}, {1, 2}},
+	{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {2, 1}, {0, 2}, {2, 2}},
+	{{0, 0}, {2, 0}, {3, 0}, {0, 1}, {1, 1}, {2, 1}, {1, 2}},
+	{{1, 0}, {3, 1}, {0, 2}, {1, 2}, {4, 2}, {5, 2}, {6, 2}},
+}
+
+const (
+	// methuselahArea is the number of cells per methuselah RandomInteresting
+	// places.
+	methuselahArea = 600
+	// noiseDensity is the fraction of cells RandomInteresting brings to
+	// life at random around the methuselahs.
+	noiseDensity = 0.03
+)
+
+// RandomInteresting clears the grid and scatters methuselahs over it in
+// random orientations, plus sparse random noise, which tends to run far
+// longer before settling than a uniform 50% fill does.
+func (g *Game) RandomInteresting(seed int64) error {
+	if g.mode != ModeLife {
+		return errors.New("random interesting patterns can only be generated in Life mode")
+	}
+	rng := rand.New(rand.NewSource(seed))
+	g.hardReset()
+	for range max(g.columns*g.rows/methuselahArea, 1) {
+		cells := methuselahs[rng.Intn(len(methuselahs))]
+		orientation := rng.Intn(8)
+		originX, originY := rng.Intn(g.columns), rng.Intn(g.rows)
+		for _, cell := range cells {
+			dx, dy := cell[0], cell[1]
+			if orientation&1 != 0 {
+				dx, dy = dy, dx
+			}
+			if orientation&2 != 0 {
+				dx = -dx
+			}
+			if orientation&4 != 0 {
+				dy = -dy
+			}
+			if x, y, ok := g.wrapCell(originX+dx, originY+dy); ok {
+				g.currentGrid[x][y] = true
+			}
+		}
+	}
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if rng.Float64() < noiseDensity {
+				g.currentGrid[i][j] = true
+			}
+		}
+	}
+	return nil
+}
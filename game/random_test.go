@@ -0,0 +1,28 @@
+package game
+
+import "testing"
+
+func TestRandomizeMultiStateModes(t *testing.T) {
+	for _, mode := range []Mode{ModeWireWorld, ModeBriansBrain} {
+		t.Run(mode.String(), func(t *testing.T) {
+			g := NewFromOptions(Options{CellSize: 10, Seed: 1})
+			t.Cleanup(g.Close)
+			g.SetMode(mode)
+			g.Randomize(SymmetryHorizontal)
+			var seen [4]int
+			for i := 0; i < g.columns; i++ {
+				for j := 0; j < g.rows; j++ {
+					seen[g.states[i][j]]++
+					if mirrored := g.states[g.columns-1-i][j]; mirrored != g.states[i][j] {
+						t.Fatalf("cell (%d, %d) is %d but its mirror image is %d", i, j, g.states[i][j], mirrored)
+					}
+				}
+			}
+			for state := range g.stateCount() {
+				if seen[state] == 0 {
+					t.Errorf("no cell got state %d", state)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"image"
+	"image/png"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// recorder writes what is drawn on screen to a numbered PNG sequence at a
+// fixed output frame rate, independent of the generation rate. Output frames
+// are timed by the wall clock, so a slow frame is repeated to keep the
+// sequence in sync.
+type recorder struct {
+	dir      string
+	fps      int
+	duration time.Duration
+	start    time.Time
+	frames   int
+}
+
+// StartRecording captures the rendered screen to dir as frame-00000.png and
+// so on, fps frames per second, for the given duration.
+func (g *Game) StartRecording(dir string, fps int, duration time.Duration) error {
+	if fps <= 0 {
+		return fmt.Errorf("recording fps must be positive, got %d", fps)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	g.recorder = &recorder{dir: dir, fps: fps, duration: duration, start: time.Now()}
+	return nil
+}
+
+func (g *Game) Recording() bool {
+	return g.recorder != nil
+}
+
+// capture writes the output frames that are due by now. It stops the
+// recording once its duration has passed or a frame fails to write.
+func (g *Game) capture(screen *ebiten.Image) {
+	r := g.recorder
+	elapsed := time.Since(r.start)
+	if elapsed > r.duration {
+		g.recorder = nil
+		return
+	}
+	due := int(elapsed.Seconds()*float64(r.fps)) + 1
+	if r.frames >= due {
+		return
+	}
+	img := image.NewRGBA(screen.Bounds())
+	screen.ReadPixels(img.Pix)
+	for ; r.frames < due; r.frames++ {
+		if err := writePNG(filepath.Join(r.dir, fmt.Sprintf("frame-%05d.png", r.frames)), img); err != nil {
+			log.Printf("recording stopped: %v", err)
+			g.recorder = nil
+			return
+		}
+	}
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// startRectangle anchors a Shift-drag rectangle at the given cell. Releasing
+// the button fills the rectangle with live cells, or clears it when the
+// anchor cell was alive.
+func (g *Game) startRectangle(cellX, cellY int) bool {
+	if g.mode != ModeLife || g.gridTopology != TopologySquare || !ebiten.IsKeyPressed(ebiten.KeyShift) {
+		return false
+	}
+	g.rectangling = true
+	g.rectangleAnchorX, g.rectangleAnchorY = cellX, cellY
+	g.rectangleX, g.rectangleY = cellX, cellY
+	return true
+}
+
+func (g *Game) dragRectangle() {
+	if !g.rectangling {
+		return
+	}
+	x, y := g.unboundedCellAt(ebiten.CursorPosition())
+	g.rectangleX, g.rectangleY = max(min(x, g.columns-1), 0), max(min(y, g.rows-1), 0)
+	if !inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		return
+	}
+	g.rectangling = false
+	g.recordEdit()
+	alive := !g.currentGrid[g.rectangleAnchorX][g.rectangleAnchorY]
+	minX, minY, maxX, maxY := g.rectangleBounds()
+	for i := minX; i <= maxX; i++ {
+		for j := minY; j <= maxY; j++ {
+			g.everAlive[i][j] = g.everAlive[i][j] || g.currentGrid[i][j] || alive
+			g.everDead[i][j] = g.everDead[i][j] || !g.currentGrid[i][j] || !alive
+			g.currentGrid[i][j] = alive
+			g.ages[i][j] = 0
+		}
+	}
+}
+
+func (g *Game) rectangleBounds() (minX, minY, maxX, maxY int) {
+	return min(g.rectangleAnchorX, g.rectangleX), min(g.rectangleAnchorY, g.rectangleY),
+		max(g.rectangleAnchorX, g.rectangleX), max(g.rectangleAnchorY, g.rectangleY)
+}
+
+// drawRectangle outlines the rectangle being dragged.
+func (g *Game) drawRectangle(dst *ebiten.Image) {
+	if !g.rectangling {
+		return
+	}
+	size := g.drawCellSize()
+	minX, minY, maxX, maxY := g.rectangleBounds()
+	vector.StrokeRect(dst, float32(minX)*size, float32(minY)*size, float32(maxX-minX+1)*size, float32(maxY-minY+1)*size, 2, previewColor, true)
+}
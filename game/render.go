@@ -0,0 +1,85 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"image/color"
+)
+
+// RenderMode picks how much besides the cells is drawn.
+type RenderMode int
+
+const (
+	// RenderMinimal only draws the live cells on black, e.g. for clean
+	// screenshots or to measure the cost of drawing the cells alone.
+	RenderMinimal RenderMode = iota
+	// RenderStandard draws the grid and overlays but no debug text.
+	RenderStandard
+	// RenderDebug also draws the debug text.
+	RenderDebug
+)
+
+func (m RenderMode) String() string {
+	switch m {
+	case RenderMinimal:
+		return "Minimal"
+	case RenderStandard:
+		return "Standard"
+	case RenderDebug:
+		return "Debug"
+	default:
+		return "Unknown"
+	}
+}
+
+// RenderBuffer returns the offscreen image the grid was last drawn into, or
+// nil when the grid is drawn straight to the screen.
+func (g *Game) RenderBuffer() *ebiten.Image {
+	return g.renderBuffer
+}
+
+// updateRenderScale fits the screen into the render buffer, keeping the
+// aspect ratio; the buffer is left blank past the grid along the other axis.
+func (g *Game) updateRenderScale() {
+	if g.renderBuffer == nil {
+		return
+	}
+	bounds := g.renderBuffer.Bounds()
+	g.renderScale = min(float32(bounds.Dx())/float32(g.screenWidth), float32(bounds.Dy())/float32(g.screenHeight))
+}
+
+// drawCellSize is the size of a cell in the image being drawn into.
+func (g *Game) drawCellSize() float32 {
+	return float32(g.cellSize) * g.renderScale
+}
+
+// RenderToImage draws the current generation onto img, e.g. to use the
+// simulation as a texture in a larger application, along with the HUD when
+// debugInfo is set. It does not advance or otherwise change the game; call
+// Layout with the size of img first for the grid to fill it.
+func (g *Game) RenderToImage(img *ebiten.Image, debugInfo bool) {
+	g.drawScene(img)
+	if debugInfo {
+		g.drawDebugInfo(img)
+	}
+}
+
+// drawLiveCells draws only the live cells, for RenderMinimal.
+func (g *Game) drawLiveCells(dst *ebiten.Image) {
+	dst.Fill(color.Black)
+	theme := g.theme()
+	size := g.drawCellSize()
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			switch {
+			case g.multiState() && g.states[i][j] != 0:
+				g.drawSprite(dst, float32(i)*size, float32(j)*size, spriteKey{shape: theme.CellShape, color: g.stateColor(theme, g.states[i][j]), fill: g.cellFill})
+			case g.multiState() || !g.currentGrid[i][j]:
+			case g.gridTopology == TopologyHex:
+				x, y := g.hexOrigin(i, j)
+				g.drawSprite(dst, x, y, spriteKey{hex: true, color: theme.CellColor, fill: g.cellFill})
+			default:
+				g.drawSprite(dst, float32(i)*size, float32(j)*size, spriteKey{shape: theme.CellShape, color: theme.CellColor, fill: g.cellFill})
+			}
+		}
+	}
+}
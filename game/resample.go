@@ -0,0 +1,52 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SetCellSize changes the cell size and resamples the grid to it. It is safe
+// to call while the simulation is running since generations are only computed
+// between updates.
+func (g *Game) SetCellSize(size int) error {
+	if g.mode != ModeLife {
+		return errors.New("cell size can only be changed in Life mode")
+	}
+	if size < g.minCellSize {
+		return fmt.Errorf("cell size must be greater than or equal to %d, got %d", g.minCellSize, size)
+	}
+	columns := min(g.screenWidth/size, maxColumns)
+	rows := min(g.screenHeight/size, maxRows)
+	if 2*g.borderCells >= min(columns, rows) {
+		return fmt.Errorf("cell size %d leaves no room inside a %d cell border", size, g.borderCells)
+	}
+	*g.currentGrid = resampleGrid(g.currentGrid, g.cellSize, size)
+	g.ages = [maxColumns][maxRows]uint16{}
+	g.cellSize, g.columns, g.rows = size, columns, rows
+	g.clearInactiveCells()
+	g.Pan(0, 0)
+	return nil
+}
+
+// resampleGrid maps oldGrid onto cells of newCellSize pixels. When zooming out
+// a new cell is alive if any old cell it covers was alive; when zooming in it
+// takes the state of the old cell under its center.
+func resampleGrid(oldGrid *Grid, oldCellSize, newCellSize int) Grid {
+	var newGrid Grid
+	for i := 0; i < maxColumns; i++ {
+		for j := 0; j < maxRows; j++ {
+			if newCellSize <= oldCellSize {
+				x := min((i*newCellSize+newCellSize/2)/oldCellSize, maxColumns-1)
+				y := min((j*newCellSize+newCellSize/2)/oldCellSize, maxRows-1)
+				newGrid[i][j] = oldGrid[x][y]
+				continue
+			}
+			for x := i * newCellSize / oldCellSize; x <= ((i+1)*newCellSize-1)/oldCellSize && x < maxColumns; x++ {
+				for y := j * newCellSize / oldCellSize; y <= ((j+1)*newCellSize-1)/oldCellSize && y < maxRows; y++ {
+					newGrid[i][j] = newGrid[i][j] || oldGrid[x][y]
+				}
+			}
+		}
+	}
+	return newGrid
+}
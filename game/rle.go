@@ -0,0 +1,210 @@
+package game
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseRLE decodes the run length encoded pattern format. Besides the usual
+// 'b' and 'o' cells it accepts the multi-state '.' and 'A'-'X' cells.
+func ParseRLE(data []byte) (*Pattern, error) {
+	p := &Pattern{}
+	var body strings.Builder
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#N"):
+			p.Name = strings.TrimSpace(line[2:])
+		case strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "x"):
+			if err := parseRLEHeader(p, line); err != nil {
+				return nil, err
+			}
+		default:
+			body.WriteString(line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := parseRLEBody(p, body.String()); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func parseRLEHeader(p *Pattern, line string) error {
+	var fields []string
+	for _, field := range strings.Split(line, ",") {
+		// A field without '=' continues the previous value, like the
+		// bounded grid suffix of "rule = B3/S23:T100,100".
+		if !strings.Contains(field, "=") && len(fields) > 0 {
+			fields[len(fields)-1] += "," + field
+			continue
+		}
+		fields = append(fields, field)
+	}
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("malformed RLE header field %q", field)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "x", "y":
+			if _, err := strconv.Atoi(value); err != nil {
+				return fmt.Errorf("malformed RLE header: %s = %q", key, value)
+			}
+		case "rule":
+			p.Rule = value
+		}
+	}
+	return nil
+}
+
+func parseRLEBody(p *Pattern, body string) error {
+	var row []uint8
+	count := 0
+	for _, c := range body {
+		switch {
+		case c >= '0' && c <= '9':
+			count = count*10 + int(c-'0')
+			continue
+		case c == 'b' || c == '.':
+			row = appendRun(row, 0, count)
+		case c == 'o':
+			row = appendRun(row, 1, count)
+		case c >= 'A' && c <= 'X':
+			row = appendRun(row, uint8(c-'A'+1), count)
+		case c == '$':
+			p.Cells = append(p.Cells, row)
+			for range max(count, 1) - 1 {
+				p.Cells = append(p.Cells, nil)
+			}
+			row = nil
+		case c == '!':
+			p.Cells = append(p.Cells, row)
+			return nil
+		default:
+			return fmt.Errorf("unexpected character %q in RLE data", c)
+		}
+		count = 0
+	}
+	return fmt.Errorf("RLE data is missing its terminating '!'")
+}
+
+func appendRun(row []uint8, state uint8, count int) []uint8 {
+	for range max(count, 1) {
+		row = append(row, state)
+	}
+	return row
+}
+
+func (g *Game) LoadRLE(data []byte, opts LoadOptions) error {
+	p, err := ParseRLE(data)
+	if err != nil {
+		return err
+	}
+	return g.LoadPattern(p, opts)
+}
+
+// StampRLE places an RLE pattern with its top-left corner at the given cell
+// without clearing the grid, so that several patterns can be combined.
+// Patterns that do not fit on the grid at that origin are rejected.
+func (g *Game) StampRLE(data []byte, originX, originY int) error {
+	p, err := ParseRLE(data)
+	if err != nil {
+		return err
+	}
+	if originX < 0 || originY < 0 || originX+p.Width() > g.columns || originY+p.Height() > g.rows {
+		return fmt.Errorf("%dx%d pattern at (%d, %d) does not fit on the %dx%d grid", p.Width(), p.Height(), originX, originY, g.columns, g.rows)
+	}
+	g.stampPattern(p, originX, originY)
+	return nil
+}
+
+// rleLineLength is the longest line EncodeRLE writes, as recommended by the
+// format.
+const rleLineLength = 70
+
+// EncodeRLE encodes p in the run length encoded format, using the
+// multi-state cells for patterns with more than two states. Patterns without
+// a rule are written as B3/S23.
+func EncodeRLE(p *Pattern) []byte {
+	multiState := false
+	for _, row := range p.Cells {
+		for _, state := range row {
+			multiState = multiState || state > 1
+		}
+	}
+	symbol := func(state uint8) byte {
+		switch {
+		case multiState && state == 0:
+			return '.'
+		case multiState:
+			return 'A' + state - 1
+		case state == 0:
+			return 'b'
+		default:
+			return 'o'
+		}
+	}
+	var runs []string
+	run := func(count int, c byte) {
+		if count > 1 {
+			runs = append(runs, strconv.Itoa(count)+string(c))
+		} else {
+			runs = append(runs, string(c))
+		}
+	}
+	pendingRows := 0
+	for y, row := range p.Cells {
+		// Trailing dead cells are implied by the end of the row.
+		end := len(row)
+		for end > 0 && row[end-1] == 0 {
+			end--
+		}
+		if end > 0 && pendingRows > 0 {
+			run(pendingRows, '$')
+			pendingRows = 0
+		}
+		for x := 0; x < end; {
+			count := 1
+			for x+count < end && row[x+count] == row[x] {
+				count++
+			}
+			run(count, symbol(row[x]))
+			x += count
+		}
+		if y < len(p.Cells)-1 {
+			pendingRows++
+		}
+	}
+	runs = append(runs, "!")
+
+	rule := p.Rule
+	if rule == "" {
+		rule = Conway.String()
+	}
+	var b bytes.Buffer
+	if p.Name != "" {
+		fmt.Fprintf(&b, "#N %s\n", p.Name)
+	}
+	fmt.Fprintf(&b, "x = %d, y = %d, rule = %s\n", p.Width(), p.Height(), rule)
+	lineLength := 0
+	for _, r := range runs {
+		if lineLength+len(r) > rleLineLength {
+			b.WriteByte('\n')
+			lineLength = 0
+		}
+		b.WriteString(r)
+		lineLength += len(r)
+	}
+	b.WriteByte('\n')
+	return b.Bytes()
+}
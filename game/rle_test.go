@@ -0,0 +1,26 @@
+package game_test
+
+import (
+	"gameoflife/game"
+	"testing"
+)
+
+func TestParseRLEHeaderRule(t *testing.T) {
+	for _, tt := range []struct{ header, rule string }{
+		{"x = 3, y = 3, rule = B3/S23", "B3/S23"},
+		{"x = 3, y = 3, rule = B3/S23:T100,100", "B3/S23:T100,100"},
+		{"x = 3, y = 3, rule = B36/S23:P64,48, extra = 1", "B36/S23:P64,48"},
+	} {
+		p, err := game.ParseRLE([]byte(tt.header + "\nbo$2bo$3o!\n"))
+		if err != nil {
+			t.Errorf("ParseRLE(%q): %v", tt.header, err)
+			continue
+		}
+		if p.Rule != tt.rule {
+			t.Errorf("ParseRLE(%q).Rule = %q, want %q", tt.header, p.Rule, tt.rule)
+		}
+	}
+	if _, err := game.ParseRLE([]byte("x = 3, y, rule = B3/S23\nbo$2bo$3o!\n")); err == nil {
+		t.Error("ParseRLE accepted a header with a malformed y")
+	}
+}
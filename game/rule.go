@@ -0,0 +1,225 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rule is an outer-totalistic Life-like rule: a dead cell is born when its
+// live neighbor count is in Born and a live cell survives when it is in
+// Survive.
+//
+// BornLetters and SurviveLetters make the rule isotropic non-totalistic:
+// when the entry for a count is set, only the arrangements of that many
+// neighbors named by its Hensel notation letters count, e.g. "ce" for 2c
+// and 2e. An empty entry allows every arrangement.
+type Rule struct {
+	Name           string
+	Born           [9]bool
+	Survive        [9]bool
+	BornLetters    [9]string
+	SurviveLetters [9]string
+}
+
+var (
+	Conway           = Rule{Name: "Life", Born: neighborCounts(3), Survive: neighborCounts(2, 3)}
+	HighLife         = Rule{Name: "HighLife", Born: neighborCounts(3, 6), Survive: neighborCounts(2, 3)}
+	DayAndNight      = Rule{Name: "Day & Night", Born: neighborCounts(3, 6, 7, 8), Survive: neighborCounts(3, 4, 6, 7, 8)}
+	Seeds            = Rule{Name: "Seeds", Born: neighborCounts(2)}
+	LifeWithoutDeath = Rule{Name: "Life without Death", Born: neighborCounts(3), Survive: neighborCounts(0, 1, 2, 3, 4, 5, 6, 7, 8)}
+	Maze             = Rule{Name: "Maze", Born: neighborCounts(3), Survive: neighborCounts(1, 2, 3, 4, 5)}
+)
+
+// RulePresets is the order in which the rule hotkey cycles through rules.
+var RulePresets = []Rule{Conway, HighLife, DayAndNight, Seeds, LifeWithoutDeath, Maze}
+
+func (r Rule) String() string {
+	var sb strings.Builder
+	sb.WriteString("B")
+	for count, born := range r.Born {
+		if born {
+			sb.WriteString(strconv.Itoa(count) + r.BornLetters[count])
+		}
+	}
+	sb.WriteString("/S")
+	for count, survives := range r.Survive {
+		if survives {
+			sb.WriteString(strconv.Itoa(count) + r.SurviveLetters[count])
+		}
+	}
+	return sb.String()
+}
+
+// totalistic reports whether the rule only depends on neighbor counts.
+func (r Rule) totalistic() bool {
+	return r.BornLetters == [9]string{} && r.SurviveLetters == [9]string{}
+}
+
+// ParseRule parses a rule in B/S notation such as "B3/S23". The halves may
+// come in either order, letters are case-insensitive and a trailing "H", as
+// used for hexagonal rules, is ignored. Counts may be followed by Hensel
+// notation letters, optionally negated with '-', as in "B2-a/S12".
+func ParseRule(s string) (Rule, error) {
+	var r Rule
+	halves := strings.Split(strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(s)), "H"), "/")
+	if len(halves) != 2 {
+		return r, fmt.Errorf("rule %q is not in B/S notation", s)
+	}
+	var seen [2]bool
+	for _, half := range halves {
+		var counts *[9]bool
+		var letters *[9]string
+		switch {
+		case strings.HasPrefix(half, "B") && !seen[0]:
+			counts, letters, seen[0] = &r.Born, &r.BornLetters, true
+		case strings.HasPrefix(half, "S") && !seen[1]:
+			counts, letters, seen[1] = &r.Survive, &r.SurviveLetters, true
+		default:
+			return r, fmt.Errorf("rule %q is not in B/S notation", s)
+		}
+		rest := strings.ToLower(half[1:])
+		for rest != "" {
+			digit := rest[0]
+			if digit < '0' || digit > '8' {
+				return r, fmt.Errorf("rule %q has invalid neighbor count %q", s, digit)
+			}
+			count := int(digit - '0')
+			rest = rest[1:]
+			negated := strings.HasPrefix(rest, "-")
+			if negated {
+				rest = rest[1:]
+			}
+			end := strings.IndexFunc(rest, func(c rune) bool { return c < 'a' || c > 'z' })
+			if end < 0 {
+				end = len(rest)
+			}
+			given := rest[:end]
+			rest = rest[end:]
+			if negated && given == "" {
+				return r, fmt.Errorf("rule %q negates no configurations of %d", s, count)
+			}
+			for i, letter := range given {
+				if !strings.ContainsRune(henselLetters[count], letter) {
+					return r, fmt.Errorf("rule %q has invalid configuration %d%c", s, count, letter)
+				}
+				if strings.ContainsRune(given[:i], letter) {
+					return r, fmt.Errorf("rule %q repeats configuration %d%c", s, count, letter)
+				}
+			}
+			if given == "" || counts[count] && letters[count] == "" {
+				counts[count], letters[count] = true, ""
+				continue
+			}
+			if negated {
+				given = excludeLetters(henselLetters[count], given)
+			}
+			if counts[count] {
+				given += letters[count]
+			}
+			if selected := excludeLetters(henselLetters[count], excludeLetters(henselLetters[count], given)); selected != "" {
+				counts[count] = true
+				if selected != henselLetters[count] {
+					letters[count] = selected
+				}
+			}
+		}
+	}
+	return r, nil
+}
+
+// excludeLetters returns the letters of all, in order, that are not in
+// excluded.
+func excludeLetters(all, excluded string) string {
+	var sb strings.Builder
+	for _, letter := range all {
+		if !strings.ContainsRune(excluded, letter) {
+			sb.WriteRune(letter)
+		}
+	}
+	return sb.String()
+}
+
+// maxCount is the largest neighbor count the rule refers to, or -1 if it
+// refers to none.
+func (r Rule) maxCount() int {
+	for count := 8; count >= 0; count-- {
+		if r.Born[count] || r.Survive[count] {
+			return count
+		}
+	}
+	return -1
+}
+
+// ruleTable indexes a rule by current state (0 dead, 1 alive) and live
+// neighbor count so the next state is a single lookup. Non-totalistic rules
+// are indexed by neighborhood instead.
+type ruleTable struct {
+	counts        [2][9]bool
+	neighborhoods *[2][512]bool
+}
+
+func precomputeRuleTable(r Rule) ruleTable {
+	var table ruleTable
+	for count := range 9 {
+		table.counts[0][count] = r.Born[count]
+		table.counts[1][count] = r.Survive[count]
+	}
+	if r.totalistic() {
+		return table
+	}
+	table.neighborhoods = &[2][512]bool{}
+	for state, half := range [2]struct {
+		counts  [9]bool
+		letters [9]string
+	}{{r.Born, r.BornLetters}, {r.Survive, r.SurviveLetters}} {
+		for count, allowed := range half.counts {
+			if !allowed {
+				continue
+			}
+			letters := half.letters[count]
+			if letters == "" {
+				letters = henselLetters[count]
+			}
+			if letters == "" {
+				// 0 and 8 neighbors have a single arrangement.
+				table.neighborhoods[state][allNeighbors*(count/8)] = true
+				continue
+			}
+			for _, letter := range []byte(letters) {
+				for _, mask := range henselNeighborhoods(count, letter) {
+					table.neighborhoods[state][mask] = true
+				}
+			}
+		}
+	}
+	return table
+}
+
+// nextLifeState is the state the cell at (x, y) has in the next generation
+// under table.
+func (g *Game) nextLifeState(table *ruleTable, x, y int) bool {
+	if table.neighborhoods != nil {
+		return table.neighborhoods[b2i(g.currentGrid[x][y])][g.neighborhood(x, y)]
+	}
+	return table.counts[b2i(g.currentGrid[x][y])][g.countLiveNeighbors(x, y)]
+}
+
+func (g *Game) setRule(r Rule) {
+	g.rule = r
+	g.ruleTable = precomputeRuleTable(r)
+}
+
+// cycleRule switches to the next preset in place, leaving the grid untouched.
+func (g *Game) cycleRule() {
+	g.rulePreset = (g.rulePreset + 1) % len(RulePresets)
+	g.setRule(RulePresets[g.rulePreset])
+}
+
+func neighborCounts(counts ...int) [9]bool {
+	var set [9]bool
+	for _, count := range counts {
+		set[count] = true
+	}
+	return set
+}
@@ -0,0 +1,113 @@
+package game_test
+
+import (
+	"gameoflife/game"
+	"gameoflife/game/testutil"
+	"testing"
+)
+
+// TestRuleTableGolden checks the lookup table evaluation of a few rules
+// against generations worked out independently.
+func TestRuleTableGolden(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		rule        string
+		rle         string
+		generations int
+		want        string
+	}{
+		{
+			name:        "Conway R-pentomino",
+			rule:        "B3/S23",
+			rle:         "b2o$2o$bo!",
+			generations: 6,
+			want: `
+				.........
+				.........
+				.........
+				.........
+				.....OOO.
+				....OO.OO
+				....O..OO
+				.....OO..`,
+		},
+		{
+			name:        "HighLife",
+			rule:        "B36/S23",
+			rle:         "b3o$o2bo$o$o!",
+			generations: 8,
+			want: `
+				............
+				............
+				............
+				............
+				............
+				..........OO
+				..OO.....OO.
+				.....O...O..
+				..O...OO....
+				...O........
+				....OOO.....`,
+		},
+		{
+			name:        "Seeds",
+			rule:        "B2/S",
+			rle:         "2o!",
+			generations: 3,
+			want: `
+				........
+				........
+				.....OO.
+				........
+				....O..O
+				........
+				....O..O
+				........
+				.....OO.`,
+		},
+		{
+			// tlife's S2-i kills the middle of a blinker, whose two
+			// neighbors sit on opposite edges.
+			name:        "tlife blinker",
+			rule:        "B3/S2-i34q",
+			rle:         "3o!",
+			generations: 1,
+			want: `
+				.......
+				.......
+				.......
+				.......
+				......O
+				.......
+				......O`,
+		},
+		{
+			name:        "tlife blinker dies out",
+			rule:        "B3/S2-i34q",
+			rle:         "3o!",
+			generations: 2,
+			want:        ".",
+		},
+		{
+			name:        "tlife block",
+			rule:        "B3/S2-i34q",
+			rle:         "2o$2o!",
+			generations: 4,
+			want: `
+				.......
+				.......
+				.......
+				.......
+				.......
+				.....OO
+				.....OO`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGame(t, game.Options{Rule: tt.rule})
+			stamp(t, g, tt.rle, 5, 5)
+			step(g, tt.generations)
+			testutil.AssertGridEquals(t, g.CurrentGrid(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package game
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+//go:embed scenarios
+var builtinScenarios embed.FS
+
+// scenarioDir is where LoadScenario looks for scenarios before falling back
+// to the built-in ones.
+const scenarioDir = "scenarios"
+
+// Scenario is a starting setup, stored as scenarios/<name>.json: the options
+// a game is created with and the pattern it starts from. The Mode,
+// Topology, GridTopology and RandomMode options are written by name, as in
+// "Mode": "Brian's Brain"; unknown names are rejected.
+type Scenario struct {
+	Name    string
+	Options Options
+	// RLE is the starting pattern. Life scenarios without one start from a
+	// random grid filled according to Options.RandomMode.
+	RLE string
+}
+
+// ScenarioNames lists the built-in scenarios, by the name LoadScenario
+// takes.
+func ScenarioNames() []string {
+	entries, _ := builtinScenarios.ReadDir("scenarios")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func readScenario(name string) (*Scenario, error) {
+	data, err := os.ReadFile(filepath.Join(scenarioDir, name+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = builtinScenarios.ReadFile("scenarios/" + name + ".json")
+		if err != nil {
+			return nil, fmt.Errorf("unknown scenario %q", name)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := &Scenario{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid scenario %q: %w", name, err)
+	}
+	return s, nil
+}
+
+// LoadScenario creates a game from the scenario with the given name.
+func LoadScenario(name string) (*Game, error) {
+	s, err := readScenario(name)
+	if err != nil {
+		return nil, err
+	}
+	g, err := newFromOptions(s.Options)
+	if err != nil {
+		return nil, fmt.Errorf("scenario %q has invalid options: %w", name, err)
+	}
+	if err := g.startScenario(s); err != nil {
+		g.Close()
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *Game) startScenario(s *Scenario) error {
+	switch {
+	case s.RLE != "":
+		return g.LoadRLE([]byte(s.RLE), LoadOptions{})
+	case g.mode != ModeLife:
+		g.hardReset()
+		return nil
+	case g.randomMode == RandomBiased:
+		return g.RandomInteresting(g.rng.Int63())
+	default:
+		g.Randomize(SymmetryNone)
+		return nil
+	}
+}
+
+// switchScenario applies a scenario to the running game: the mode, rule,
+// cell size and edges of its options and its starting pattern. Options that
+// can only be set when a game is created are left alone.
+func (g *Game) switchScenario(s *Scenario) error {
+	opts := s.Options
+	if opts.GridTopology != g.gridTopology {
+		return fmt.Errorf("scenario %q needs a %s grid", s.Name, opts.GridTopology)
+	}
+	rule := Conway
+	if g.gridTopology == TopologyHex {
+		rule = HexRule
+	}
+	if opts.Rule != "" {
+		var err error
+		if rule, err = ParseRule(opts.Rule); err != nil {
+			return fmt.Errorf("scenario %q has an invalid rule: %w", s.Name, err)
+		}
+		if err := g.gridTopology.checkRule(rule); err != nil {
+			return fmt.Errorf("scenario %q has an invalid rule: %w", s.Name, err)
+		}
+	}
+	g.SetMode(ModeLife)
+	if opts.CellSize != g.cellSize {
+		if err := g.SetCellSize(opts.CellSize); err != nil {
+			return fmt.Errorf("scenario %q: %w", s.Name, err)
+		}
+	}
+	g.wrapX = opts.Topology == TopologyToroidal || opts.WrapX
+	g.wrapY = opts.Topology == TopologyToroidal || opts.WrapY
+	g.setRule(rule)
+	g.randomMode = opts.RandomMode
+	if opts.Mode != ModeLife {
+		g.SetMode(opts.Mode)
+	}
+	return g.startScenario(s)
+}
+
+// SwitchScenario applies the scenario with the given name, as LoadScenario
+// takes it, to the running game.
+func (g *Game) SwitchScenario(name string) error {
+	s, err := readScenario(name)
+	if err != nil {
+		return err
+	}
+	return g.switchScenario(s)
+}
+
+// scenarioList lists the scenarios for selectScenario.
+func (g *Game) scenarioList() string {
+	msg := "\n\n" + g.t("Scenarios, press a number to start one:")
+	for i, s := range g.listedScenarios() {
+		msg += fmt.Sprintf("\n%d. %s", i+1, s.Name)
+	}
+	return msg
+}
+
+// listedScenarios reads the scenarios once: the built-in ones in
+// ScenarioNames order, then the other ones in scenarioDir by name.
+func (g *Game) listedScenarios() []*Scenario {
+	if g.scenarios == nil {
+		names := ScenarioNames()
+		entries, _ := os.ReadDir(scenarioDir)
+		for _, entry := range entries {
+			if name, ok := strings.CutSuffix(entry.Name(), ".json"); ok && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		for _, name := range names {
+			if s, err := readScenario(name); err == nil {
+				g.scenarios = append(g.scenarios, s)
+			} else {
+				log.Printf("skipping scenario: %v", err)
+			}
+		}
+	}
+	return g.scenarios
+}
+
+// selectScenario starts the listed scenario whose number was just pressed.
+// With more than nine scenarios the digit opens a prompt for the whole
+// number instead.
+func (g *Game) selectScenario() {
+	scenarios := g.listedScenarios()
+	for i := range min(len(scenarios), 9) {
+		if !inpututil.IsKeyJustPressed(ebiten.KeyDigit1 + ebiten.Key(i)) {
+			continue
+		}
+		if len(scenarios) > 9 {
+			g.entry.open(g.t("Scenario"), g.selectScenarioNumber)
+			g.entry.digits = append(g.entry.digits, rune('1'+i))
+			return
+		}
+		g.selectScenarioNumber(i + 1)
+		return
+	}
+}
+
+// selectScenarioNumber starts the n-th listed scenario.
+func (g *Game) selectScenarioNumber(n int) {
+	scenarios := g.listedScenarios()
+	if n < 1 || n > len(scenarios) {
+		log.Printf("could not start the scenario: there is no scenario %d", n)
+		return
+	}
+	g.showScenarios = false
+	if err := g.switchScenario(scenarios[n-1]); err != nil {
+		log.Printf("could not start the scenario: %v", err)
+	}
+}
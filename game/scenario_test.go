@@ -0,0 +1,56 @@
+package game
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeScenario saves a scenario into scenarioDir under the current
+// directory.
+func writeScenario(t *testing.T, name, data string) {
+	t.Helper()
+	if err := os.MkdirAll(scenarioDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(scenarioDir, name+".json"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadScenarioRejectsInvalidOptions(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeScenario(t, "bad", `{"Name": "Bad", "Options": {"CellSize": -1}}`)
+	if g, err := LoadScenario("bad"); err == nil {
+		g.Close()
+		t.Error("LoadScenario accepted a negative cell size")
+	}
+}
+
+// TestSelectTenthScenario checks that scenarios past the ninth, which have
+// no digit key of their own, can be started by number and by name.
+func TestSelectTenthScenario(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for i := len(ScenarioNames()); i < 10; i++ {
+		writeScenario(t, fmt.Sprintf("extra-%02d", i+1), fmt.Sprintf(`{"Name": "Extra %d", "Options": {"CellSize": 10}, "RLE": "bo$2bo$3o!"}`, i+1))
+	}
+	g := NewFromOptions(Options{CellSize: 10})
+	t.Cleanup(g.Close)
+	if scenarios := g.listedScenarios(); len(scenarios) != 10 || scenarios[9].Name != "Extra 10" {
+		t.Fatalf("listed %d scenarios, want the built-in ones and the extra ones", len(scenarios))
+	}
+	g.showScenarios = true
+	g.selectScenarioNumber(10)
+	if g.showScenarios || g.Population() != 5 {
+		t.Errorf("selecting scenario 10 left population %d, want the 5 cells of its glider", g.Population())
+	}
+
+	g.hardReset()
+	if err := g.SwitchScenario("extra-10"); err != nil {
+		t.Fatalf("SwitchScenario: %v", err)
+	}
+	if g.Population() != 5 {
+		t.Errorf("SwitchScenario left population %d, want 5", g.Population())
+	}
+}
@@ -0,0 +1,34 @@
+package game
+
+import (
+	"fmt"
+	"log"
+)
+
+// updateSession adds a generation with the given population to the session
+// totals.
+func (g *Game) updateSession(population int, wasAlive bool) {
+	g.sessionGeneration++
+	g.sessionMaxPop = max(g.sessionMaxPop, population)
+	if wasAlive && population == 0 {
+		g.sessionExtinctions++
+	}
+}
+
+// summarizeSession describes the session so far, and whether the run being
+// reset settled into a still life or an oscillator, for the HUD.
+func (g *Game) summarizeSession() {
+	if g.sessionGeneration == 0 {
+		return
+	}
+	stable := g.periods.period != 0 && g.periods.generation == g.generation
+	g.sessionSummary = fmt.Sprintf(g.t("Session: gen=%d, maxPop=%d, extinctions=%d, stable=%t"), g.sessionGeneration, g.sessionMaxPop, g.sessionExtinctions, stable)
+	log.Print(g.sessionSummary)
+}
+
+// NewSession clears the totals that are otherwise kept across resets and
+// summarized by each of them.
+func (g *Game) NewSession() {
+	g.sessionMaxPop, g.sessionExtinctions, g.sessionGeneration = 0, 0, 0
+	g.sessionSummary = ""
+}
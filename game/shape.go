@@ -0,0 +1,102 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+type CellShape int
+
+const (
+	CellShapeRect CellShape = iota
+	CellShapeCircle
+	CellShapeDiamond
+)
+
+func (s CellShape) String() string {
+	switch s {
+	case CellShapeRect:
+		return "Rect"
+	case CellShapeCircle:
+		return "Circle"
+	case CellShapeDiamond:
+		return "Diamond"
+	default:
+		return "Unknown"
+	}
+}
+
+// drawCell fills the cell whose top-left corner is at (x, y) with the given
+// shape.
+func drawCell(dst *ebiten.Image, x, y, size float32, shape CellShape, c color.Color) {
+	switch shape {
+	case CellShapeCircle:
+		vector.DrawFilledCircle(dst, x+size/2, y+size/2, max(size/2-1, 0.5), c, true)
+	case CellShapeDiamond:
+		half := size / 2
+		drawFilledPolygon(dst, []float32{x + half, y, x + size, y + half, x + half, y + size, x, y + half}, c)
+	default:
+		vector.DrawFilledRect(dst, x, y, size, size, c, true)
+	}
+}
+
+// patternDotSpacing is the distance in pixels between the dots marking a
+// dead cell in patterned mode.
+const patternDotSpacing = 4
+
+// drawDeadCellPattern marks the dead cell whose top-left corner is at (x, y)
+// with a grid of single pixel dots.
+func drawDeadCellPattern(dst *ebiten.Image, x, y, size float32, c color.Color) {
+	for dx := float32(patternDotSpacing / 2); dx < size; dx += patternDotSpacing {
+		for dy := float32(patternDotSpacing / 2); dy < size; dy += patternDotSpacing {
+			vector.DrawFilledRect(dst, x+dx, y+dy, 1, 1, c, false)
+		}
+	}
+}
+
+var (
+	whitePixelOnce sync.Once
+	whitePixel     *ebiten.Image
+)
+
+// solidPixel is a white source image for drawing untextured triangles.
+func solidPixel() *ebiten.Image {
+	whitePixelOnce.Do(func() {
+		img := ebiten.NewImage(3, 3)
+		img.Fill(color.White)
+		whitePixel = img.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+	})
+	return whitePixel
+}
+
+func colorVertices(vertices []ebiten.Vertex, c color.Color) {
+	r, g, b, a := c.RGBA()
+	for i := range vertices {
+		vertices[i].SrcX, vertices[i].SrcY = 1, 1
+		vertices[i].ColorR = float32(r) / 0xffff
+		vertices[i].ColorG = float32(g) / 0xffff
+		vertices[i].ColorB = float32(b) / 0xffff
+		vertices[i].ColorA = float32(a) / 0xffff
+	}
+}
+
+func polygonPath(path *vector.Path, points []float32) {
+	path.MoveTo(points[0], points[1])
+	for i := 2; i+1 < len(points); i += 2 {
+		path.LineTo(points[i], points[i+1])
+	}
+	path.Close()
+}
+
+// drawFilledPolygon fills the polygon given as consecutive x, y pairs.
+func drawFilledPolygon(dst *ebiten.Image, points []float32, c color.Color) {
+	var path vector.Path
+	polygonPath(&path, points)
+	vertices, indices := path.AppendVerticesAndIndicesForFilling(nil, nil)
+	colorVertices(vertices, c)
+	dst.DrawTriangles(vertices, indices, solidPixel(), &ebiten.DrawTrianglesOptions{AntiAlias: true})
+}
@@ -0,0 +1,40 @@
+package game
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestDrawCellShapes(t *testing.T) {
+	const size = 20
+	for _, tt := range []struct {
+		shape CellShape
+		// corner is whether the shape covers the corners of the cell.
+		corner bool
+	}{
+		{CellShapeRect, true},
+		{CellShapeCircle, false},
+		{CellShapeDiamond, false},
+	} {
+		t.Run(tt.shape.String(), func(t *testing.T) {
+			img := ebiten.NewImage(size, size)
+			defer img.Deallocate()
+			drawCell(img, 0, 0, size, tt.shape, color.White)
+			if !painted(img, size/2, size/2) {
+				t.Error("the center of the cell is not painted")
+			}
+			for _, corner := range [][2]int{{1, 1}, {size - 2, 1}, {1, size - 2}, {size - 2, size - 2}} {
+				if got := painted(img, corner[0], corner[1]); got != tt.corner {
+					t.Errorf("corner (%d, %d) painted = %t, want %t", corner[0], corner[1], got, tt.corner)
+				}
+			}
+		})
+	}
+}
+
+func painted(img *ebiten.Image, x, y int) bool {
+	_, _, _, a := img.At(x, y).RGBA()
+	return a > 0xffff/2
+}
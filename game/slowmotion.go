@@ -0,0 +1,32 @@
+package game
+
+const (
+	// slowMotionDelta is the population change between two generations that
+	// doubles the time per generation in slow-motion mode.
+	slowMotionDelta = 50
+	// slowMotionMaxFactor bounds how far slow-motion mode slows down.
+	slowMotionMaxFactor = 6
+)
+
+// updateSlowMotion slows the game down right away when the population
+// changes sharply, then speeds it back up one tick per generation.
+func (g *Game) updateSlowMotion(population int) {
+	previous := g.lastPopulation
+	continuous := g.lastPopulationGeneration == g.generation-1
+	g.lastPopulation, g.lastPopulationGeneration = population, g.generation
+	if !continuous {
+		// The game was reset or its generation was set since the last count.
+		return
+	}
+	delta := population - previous
+	if delta < 0 {
+		delta = -delta
+	}
+	factor := min(1+delta/slowMotionDelta, slowMotionMaxFactor)
+	target := g.baseTicksPerGeneration * float64(factor)
+	if target > g.ticksPerGeneration {
+		g.ticksPerGeneration = target
+	} else if g.ticksPerGeneration > g.baseTicksPerGeneration {
+		g.ticksPerGeneration = max(g.ticksPerGeneration-1, g.baseTicksPerGeneration)
+	}
+}
@@ -0,0 +1,122 @@
+package game
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/ebitengine/oto/v3"
+	"math"
+	"math/rand"
+)
+
+const (
+	soundSampleRate = 44100
+	soundVolumeStep = 0.1
+	// soundFullCount is the number of births or deaths in one generation at
+	// which the corresponding sound plays at full volume.
+	soundFullCount = 256
+)
+
+// soundPlayer plays one short synthesized frame per generation that mixes a
+// "ping" for births with a "pop" for deaths.
+type soundPlayer struct {
+	context *oto.Context
+	ready   chan struct{}
+	ping    []float64
+	pop     []float64
+	volume  float64
+	// player holds on to the last frame while it plays.
+	player *oto.Player
+}
+
+func newSoundPlayer() (*soundPlayer, error) {
+	context, ready, err := oto.NewContext(&oto.NewContextOptions{
+		SampleRate:   soundSampleRate,
+		ChannelCount: 1,
+		Format:       oto.FormatSignedInt16LE,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &soundPlayer{
+		context: context,
+		ready:   ready,
+		ping:    synthesizePing(),
+		pop:     synthesizePop(),
+		volume:  0.5,
+	}, nil
+}
+
+// synthesizePing is a bright sine tone with an exponential decay.
+func synthesizePing() []float64 {
+	samples := make([]float64, soundSampleRate*80/1000)
+	for i := range samples {
+		t := float64(i) / soundSampleRate
+		samples[i] = math.Sin(2*math.Pi*1760*t) * math.Exp(-t*50)
+	}
+	return samples
+}
+
+// synthesizePop is a burst of low-passed noise that dies out quickly.
+func synthesizePop() []float64 {
+	rng := rand.New(rand.NewSource(1))
+	samples := make([]float64, soundSampleRate*40/1000)
+	last := 0.0
+	for i := range samples {
+		t := float64(i) / soundSampleRate
+		last += (rng.Float64()*2 - 1 - last) * 0.2
+		samples[i] = last * 2 * math.Exp(-t*100)
+	}
+	return samples
+}
+
+// loudness scales the event count logarithmically so that a handful of
+// events is still audible without large generations clipping.
+func loudness(count int) float64 {
+	return min(math.Log1p(float64(count))/math.Log1p(soundFullCount), 1)
+}
+
+func (s *soundPlayer) play(births, deaths int) {
+	select {
+	case <-s.ready:
+	default:
+		return
+	}
+	if s.volume == 0 || births+deaths == 0 {
+		return
+	}
+	pingGain, popGain := loudness(births)*s.volume/2, loudness(deaths)*s.volume/2
+	frame := make([]byte, 2*max(len(s.ping), len(s.pop)))
+	for i := 0; i < len(frame)/2; i++ {
+		var sample float64
+		if i < len(s.ping) {
+			sample += s.ping[i] * pingGain
+		}
+		if i < len(s.pop) {
+			sample += s.pop[i] * popGain
+		}
+		sample = max(min(sample, 1), -1)
+		binary.LittleEndian.PutUint16(frame[2*i:], uint16(int16(sample*math.MaxInt16)))
+	}
+	s.player = s.context.NewPlayer(bytes.NewReader(frame))
+	s.player.Play()
+}
+
+func (s *soundPlayer) changeVolume(delta float64) {
+	s.volume = max(min(math.Round((s.volume+delta)*10)/10, 1), 0)
+}
+
+// countTransitions counts the cells that were born and died going from prev
+// to the current grid.
+func (g *Game) countTransitions(prev *Grid) (births, deaths int) {
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			switch was, is := prev[i][j], g.currentGrid[i][j]; {
+			case !was && is:
+				births++
+			case was && !is:
+				deaths++
+			}
+		}
+	}
+	return births, deaths
+}
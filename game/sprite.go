@@ -0,0 +1,57 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"image/color"
+	"math"
+)
+
+type spriteKey struct {
+	shape CellShape
+	hex   bool
+	color color.Color
+	fill  CellFillStyle
+}
+
+// cellSprites caches a pre-rendered image of a single cell per shape and
+// color. Drawing every cell from the same image lets Ebitengine batch them
+// into a handful of draw calls instead of tessellating each cell.
+type cellSprites struct {
+	size   float32
+	images map[spriteKey]*ebiten.Image
+}
+
+func (s *cellSprites) sprite(size float32, key spriteKey) *ebiten.Image {
+	if s.size != size || s.images == nil {
+		for _, img := range s.images {
+			img.Deallocate()
+		}
+		s.size, s.images = size, map[spriteKey]*ebiten.Image{}
+	}
+	if img, ok := s.images[key]; ok {
+		return img
+	}
+	var img *ebiten.Image
+	side := int(math.Ceil(float64(size)))
+	if key.hex {
+		img = ebiten.NewImage(side, int(math.Ceil(float64(size*4/3))))
+		drawFilledPolygon(img, hexagon(0, size/6, size), key.color)
+	} else {
+		img = ebiten.NewImage(side, side)
+		drawCell(img, 0, 0, size, key.shape, key.color)
+	}
+	drawFillPattern(img, size, key.fill, key.color)
+	s.images[key] = img
+	return img
+}
+
+// drawSprite draws the sprite for a cell whose top-left corner is at (x, y).
+func (g *Game) drawSprite(dst *ebiten.Image, x, y float32, key spriteKey) {
+	size := g.drawCellSize()
+	if key.hex {
+		y -= size / 6
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	dst.DrawImage(g.sprites.sprite(size, key), op)
+}
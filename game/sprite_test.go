@@ -0,0 +1,42 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// benchmarkDrawCells draws the live cells of a 50% dense 64x48 grid with
+// draw, flushing the draw commands every iteration.
+func benchmarkDrawCells(b *testing.B, draw func(g *Game, dst *ebiten.Image, x, y, size float32)) {
+	g := newRandomGame(b, Options{CellSize: 10, Seed: 1})
+	dst := ebiten.NewImage(ScreenWidth, ScreenHeight)
+	defer dst.Deallocate()
+	size := g.drawCellSize()
+	b.ResetTimer()
+	for range b.N {
+		for i := 0; i < g.columns; i++ {
+			for j := 0; j < g.rows; j++ {
+				if g.currentGrid[i][j] {
+					draw(g, dst, float32(i)*size, float32(j)*size, size)
+				}
+			}
+		}
+		dst.At(0, 0)
+	}
+}
+
+// BenchmarkDrawCellsVector draws every cell as its own filled rectangle, the
+// way drawGrid did before the sprites.
+func BenchmarkDrawCellsVector(b *testing.B) {
+	benchmarkDrawCells(b, func(g *Game, dst *ebiten.Image, x, y, size float32) {
+		vector.DrawFilledRect(dst, x, y, size, size, g.theme().CellColor, true)
+	})
+}
+
+func BenchmarkDrawCellsSprites(b *testing.B) {
+	benchmarkDrawCells(b, func(g *Game, dst *ebiten.Image, x, y, size float32) {
+		g.drawSprite(dst, x, y, spriteKey{color: g.theme().CellColor})
+	})
+}
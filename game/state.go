@@ -0,0 +1,122 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+)
+
+// SavedState is the JSON form of a game written by SaveState. Grid is packed
+// by MarshalGrid and States holds the Wire World state of every cell of the
+// grid storage in row-major order.
+type SavedState struct {
+	Mode           Mode             `json:"mode"`
+	Generation     int              `json:"generation"`
+	Rule           Rule             `json:"rule"`
+	RulePreset     int              `json:"rulePreset"`
+	ElementaryRule byte             `json:"elementaryRule"`
+	ElementaryRow  int              `json:"elementaryRow"`
+	ElementarySeed [maxColumns]bool `json:"elementarySeed"`
+	Theme          ThemeID          `json:"theme"`
+	Grid           []byte           `json:"grid"`
+	States         []byte           `json:"states"`
+}
+
+// SaveState writes the simulation state, but not the options the game was
+// created with, as JSON to w.
+func (g *Game) SaveState(w io.Writer) error {
+	states := make([]byte, 0, maxColumns*maxRows)
+	for j := 0; j < maxRows; j++ {
+		for i := 0; i < maxColumns; i++ {
+			states = append(states, g.states[i][j])
+		}
+	}
+	return json.NewEncoder(w).Encode(SavedState{
+		Mode:           g.mode,
+		Generation:     g.generation,
+		Rule:           g.rule,
+		RulePreset:     g.rulePreset,
+		ElementaryRule: g.elementaryRule,
+		ElementaryRow:  g.elementaryRow,
+		ElementarySeed: g.elementarySeed,
+		Theme:          g.selectedThemeID,
+		Grid:           g.MarshalGrid(),
+		States:         states,
+	})
+}
+
+// LoadState restores a state written by SaveState for a grid of the same
+// size. The game is paused afterwards.
+func (g *Game) LoadState(r io.Reader) error {
+	var saved SavedState
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return err
+	}
+	if saved.Mode < ModeLife || saved.Mode >= modeCount {
+		return fmt.Errorf("saved state has unknown mode %d", saved.Mode)
+	}
+	if err := g.checkMarshaledGrid(saved.Grid); err != nil {
+		return fmt.Errorf("saved state does not fit the grid: %w", err)
+	}
+	if len(saved.States) != maxColumns*maxRows {
+		return fmt.Errorf("saved states must cover %d cells, got %d", maxColumns*maxRows, len(saved.States))
+	}
+	if saved.Theme < 0 || int(saved.Theme) >= len(g.themes) || saved.RulePreset < 0 || saved.RulePreset >= len(RulePresets) {
+		return fmt.Errorf("saved state refers to an unknown theme or rule preset")
+	}
+	if err := g.gridTopology.checkRule(saved.Rule); err != nil {
+		return fmt.Errorf("saved state has an invalid rule: %w", err)
+	}
+	g.SetMode(saved.Mode)
+	g.elementarySeed = saved.ElementarySeed
+	if err := g.UnmarshalGrid(saved.Grid); err != nil {
+		return err
+	}
+	for j := 0; j < maxRows; j++ {
+		for i := 0; i < maxColumns; i++ {
+			g.states[i][j] = saved.States[j*maxColumns+i] % g.stateCount()
+		}
+	}
+	g.clearInactiveCells()
+	g.setRule(saved.Rule)
+	g.rulePreset = saved.RulePreset
+	g.elementaryRule = saved.ElementaryRule
+	g.elementaryRow = min(max(saved.ElementaryRow, 0), g.rows-1)
+	g.selectedThemeID = saved.Theme
+	g.generation = saved.Generation
+	g.state = Paused
+	return nil
+}
+
+// loadAutoPersisted restores the state saved at the auto-persist path, if
+// any. A broken save is reported and skipped rather than keeping the game
+// from starting.
+func (g *Game) loadAutoPersisted() {
+	f, err := os.Open(g.autoPersistPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err == nil {
+		defer f.Close()
+		err = g.LoadState(f)
+	}
+	if err != nil {
+		log.Printf("failed to restore the last session: %v", err)
+	}
+}
+
+func (g *Game) saveAutoPersisted() error {
+	f, err := os.Create(g.autoPersistPath)
+	if err != nil {
+		return err
+	}
+	if err := g.SaveState(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
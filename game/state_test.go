@@ -0,0 +1,48 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSaveStateRoundTripBriansBrain(t *testing.T) {
+	g := NewFromOptions(Options{CellSize: 10})
+	t.Cleanup(g.Close)
+	g.SetMode(ModeBriansBrain)
+	g.states[10][10], g.states[11][10], g.states[12][11] = BrainFiring, BrainDying, BrainFiring
+	g.cycle()
+	var saved bytes.Buffer
+	if err := g.SaveState(&saved); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	loaded := NewFromOptions(Options{CellSize: 10})
+	t.Cleanup(loaded.Close)
+	if err := loaded.LoadState(&saved); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if loaded.mode != ModeBriansBrain || loaded.generation != 1 {
+		t.Errorf("loaded %s at generation %d, want %s at generation 1", loaded.mode, loaded.generation, ModeBriansBrain)
+	}
+	if *loaded.states != *g.states {
+		t.Error("loaded Brian's Brain states differ from the saved ones")
+	}
+}
+
+func TestLoadStateChecksRuleAgainstTopology(t *testing.T) {
+	square := NewFromOptions(Options{CellSize: 10})
+	t.Cleanup(square.Close)
+	var saved bytes.Buffer
+	if err := square.SaveState(&saved); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+
+	hex := NewFromOptions(Options{CellSize: 10, GridTopology: TopologyHex})
+	t.Cleanup(hex.Close)
+	if err := hex.LoadState(&saved); err == nil {
+		t.Error("hex grid loaded a state with Conway's 8-neighbor rule")
+	}
+	if hex.rule != HexRule {
+		t.Errorf("rejected state changed the rule to %s", hex.rule)
+	}
+}
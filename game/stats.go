@@ -0,0 +1,164 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+type RunHeader struct {
+	Rule    string `json:"rule"`
+	Seed    int64  `json:"seed"`
+	Columns int    `json:"columns"`
+	Rows    int    `json:"rows"`
+}
+
+type GenerationStats struct {
+	Generation int  `json:"generation"`
+	Population int  `json:"population"`
+	Width      int  `json:"width"`
+	Height     int  `json:"height"`
+	Stabilized bool `json:"stabilized"`
+	Extinct    bool `json:"extinct"`
+}
+
+type RunReport struct {
+	Header      RunHeader         `json:"header"`
+	Generations []GenerationStats `json:"generations"`
+}
+
+// RunStats steps the simulation the given number of generations without
+// rendering and writes a RunReport as JSON to w.
+func (g *Game) RunStats(generations int, w io.Writer) error {
+	if generations < 0 {
+		return fmt.Errorf("generations must be non-negative, got %d", generations)
+	}
+	report := RunReport{
+		Header: RunHeader{
+			Rule:    g.ruleString(),
+			Seed:    g.seed,
+			Columns: g.columns,
+			Rows:    g.rows,
+		},
+		Generations: make([]GenerationStats, 0, generations),
+	}
+	for range generations {
+		previousGrid, previousStates := *g.currentGrid, *g.states
+		g.Step()
+		population := g.Population()
+		width, height := g.BoundingBoxSize()
+		report.Generations = append(report.Generations, GenerationStats{
+			Generation: g.generation,
+			Population: population,
+			Width:      width,
+			Height:     height,
+			Stabilized: *g.currentGrid == previousGrid && *g.states == previousStates,
+			Extinct:    population == 0,
+		})
+	}
+	return json.NewEncoder(w).Encode(report)
+}
+
+// Population returns the number of live cells, or the number of electron
+// heads in Wire World mode.
+func (g *Game) Population() int {
+	population := 0
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if g.isAlive(i, j) {
+				population++
+			}
+		}
+	}
+	return population
+}
+
+// QuadrantPopulations returns the Population of the top-left, top-right,
+// bottom-left and bottom-right quadrants of the grid, split at columns/2 and
+// rows/2.
+func (g *Game) QuadrantPopulations() [4]int {
+	var quadrants [4]int
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if !g.isAlive(i, j) {
+				continue
+			}
+			q := 0
+			if i >= g.columns/2 {
+				q++
+			}
+			if j >= g.rows/2 {
+				q += 2
+			}
+			quadrants[q]++
+		}
+	}
+	return quadrants
+}
+
+// Centroid returns the average cell coordinates of the live cells, or NaN
+// for both when the grid is empty.
+func (g *Game) Centroid() (float64, float64) {
+	var sumX, sumY, population int
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if g.isAlive(i, j) {
+				sumX, sumY, population = sumX+i, sumY+j, population+1
+			}
+		}
+	}
+	if population == 0 {
+		return math.NaN(), math.NaN()
+	}
+	return float64(sumX) / float64(population), float64(sumY) / float64(population)
+}
+
+// BoundingBoxSize returns the width and height of the smallest rectangle
+// holding every live cell, or zeros for an empty grid.
+func (g *Game) BoundingBoxSize() (int, int) {
+	minX, minY, maxX, maxY := g.columns, g.rows, -1, -1
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if g.isAlive(i, j) {
+				minX, maxX = min(minX, i), max(maxX, i)
+				minY, maxY = min(minY, j), max(maxY, j)
+			}
+		}
+	}
+	if maxX < 0 {
+		return 0, 0
+	}
+	return maxX - minX + 1, maxY - minY + 1
+}
+
+func (g *Game) isAlive(x, y int) bool {
+	switch g.mode {
+	case ModeWireWorld:
+		return g.states[x][y] == WireHead
+	case ModeBriansBrain:
+		return g.states[x][y] == BrainFiring
+	}
+	return g.currentGrid[x][y]
+}
+
+// LiveCells returns the x, y coordinates of the live cells in a newly
+// allocated slice, in column-major order: by x, then by y within a column.
+// Cells outside the active region are always dead, so only it contributes.
+func (grid *Grid) LiveCells() [][2]int {
+	var cells [][2]int
+	for x := range grid {
+		for y := range grid[x] {
+			if grid[x][y] {
+				cells = append(cells, [2]int{x, y})
+			}
+		}
+	}
+	return cells
+}
+
+// CurrentGrid returns a copy of the grid holding the current generation.
+func (g *Game) CurrentGrid() *Grid {
+	grid := *g.currentGrid
+	return &grid
+}
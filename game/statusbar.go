@@ -0,0 +1,22 @@
+package game
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"image/color"
+)
+
+// StatusBarHeight is the height of the strip below the grid that always
+// shows the rule, topology and grid size, one line of the default HUD font.
+// The window needs to be this much taller than the grid.
+const StatusBarHeight = 16
+
+var statusBarColor = color.NRGBA{0x20, 0x20, 0x20, 0xff}
+
+func (g *Game) drawStatusBar(screen *ebiten.Image) {
+	y := screen.Bounds().Dy() - StatusBarHeight
+	vector.DrawFilledRect(screen, 0, float32(y), float32(screen.Bounds().Dx()), StatusBarHeight, statusBarColor, false)
+	text := fmt.Sprintf("[%s: %s] [%s: %s] [%s: %dx%d] [%s: %d]", g.t("Rule"), g.ruleString(), g.t("Topo"), g.topologyName(), g.t("Size"), g.columns, g.rows, g.t("CellSize"), g.cellSize)
+	g.drawLabel(screen, text, 4, float64(y)+(StatusBarHeight-g.hudFace.Size)/2)
+}
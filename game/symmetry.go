@@ -0,0 +1,58 @@
+package game
+
+type Symmetry int
+
+const (
+	// SymmetryNone leaves the grid as it is.
+	SymmetryNone Symmetry = iota
+	// SymmetryHorizontal mirrors the grid left to right.
+	SymmetryHorizontal
+	// SymmetryVertical mirrors the grid top to bottom.
+	SymmetryVertical
+	// SymmetryFourFold mirrors the grid along both axes.
+	SymmetryFourFold
+)
+
+func (s Symmetry) String() string {
+	switch s {
+	case SymmetryNone:
+		return "None"
+	case SymmetryHorizontal:
+		return "Horizontal"
+	case SymmetryVertical:
+		return "Vertical"
+	case SymmetryFourFold:
+		return "Four-fold"
+	default:
+		return "Unknown"
+	}
+}
+
+// Symmetrize combines the grid with its reflections so that it becomes
+// symmetric: a cell is alive when it or any of its mirror images is. In Wire
+// World mode empty cells take the state of a non-empty mirror image.
+func (g *Game) Symmetrize(s Symmetry) {
+	mirrorX := s == SymmetryHorizontal || s == SymmetryFourFold
+	mirrorY := s == SymmetryVertical || s == SymmetryFourFold
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			mi, mj := i, j
+			if mirrorX {
+				mi = g.columns - 1 - i
+			}
+			if mirrorY {
+				mj = g.rows - 1 - j
+			}
+			for _, cell := range [][2]int{{mi, j}, {i, mj}, {mi, mj}} {
+				x, y := cell[0], cell[1]
+				if g.multiState() {
+					if g.states[x][y] == 0 {
+						g.states[x][y] = g.states[i][j]
+					}
+				} else if g.currentGrid[i][j] {
+					g.currentGrid[x][y] = true
+				}
+			}
+		}
+	}
+}
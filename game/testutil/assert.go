@@ -0,0 +1,114 @@
+// Package testutil holds helpers for tests of the game package. It is only
+// meant to be imported from tests so it never ends up in the main binary.
+package testutil
+
+import (
+	"fmt"
+	"gameoflife/game"
+	"strings"
+	"testing"
+)
+
+// AssertGridEquals fails t unless the top-left region of g matches ascii,
+// where '.' is a dead cell and 'O' or '*' a live one. Blank lines around the
+// block and its common indentation are ignored so it can be written as an
+// indented raw string. Live cells outside the region also fail the
+// assertion. On failure both grids are printed side by side.
+func AssertGridEquals(t testing.TB, g *game.Grid, ascii string) {
+	t.Helper()
+	want, err := parseASCII(ascii)
+	if err != nil {
+		t.Fatalf("AssertGridEquals: %v", err)
+	}
+	width := 0
+	for _, row := range want {
+		width = max(width, len(row))
+	}
+	if width > len(g) || len(want) > len(g[0]) {
+		t.Fatalf("AssertGridEquals: %dx%d block does not fit on the grid", width, len(want))
+	}
+
+	// The region grows to cover any live cell outside the expected block so
+	// that it shows up in the diff.
+	columns, rows := width, len(want)
+	for x := range g {
+		for y := range g[x] {
+			if g[x][y] {
+				columns, rows = max(columns, x+1), max(rows, y+1)
+			}
+		}
+	}
+	wanted := func(x, y int) bool {
+		return y < len(want) && x < len(want[y]) && want[y][x]
+	}
+
+	var diff strings.Builder
+	mismatches := 0
+	fmt.Fprintf(&diff, "  %-*s   %s\n", columns, "want", "got")
+	for y := range rows {
+		var left, right strings.Builder
+		rowDiffers := false
+		for x := range columns {
+			left.WriteByte(cellChar(wanted(x, y)))
+			right.WriteByte(cellChar(g[x][y]))
+			if wanted(x, y) != g[x][y] {
+				rowDiffers = true
+				mismatches++
+			}
+		}
+		marker := ' '
+		if rowDiffers {
+			marker = '>'
+		}
+		fmt.Fprintf(&diff, "%c %s | %s\n", marker, left.String(), right.String())
+	}
+	if mismatches > 0 {
+		t.Errorf("grid differs from expected in %d cells:\n%s", mismatches, diff.String())
+	}
+}
+
+func parseASCII(ascii string) ([][]bool, error) {
+	lines := strings.Split(ascii, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	cells := make([][]bool, len(lines))
+	for y, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if len(line) >= indent {
+			line = line[max(indent, 0):]
+		}
+		cells[y] = make([]bool, len(line))
+		for x, c := range line {
+			switch c {
+			case '.':
+			case 'O', '*':
+				cells[y][x] = true
+			default:
+				return nil, fmt.Errorf("line %d: unexpected character %q", y+1, c)
+			}
+		}
+	}
+	return cells, nil
+}
+
+func cellChar(alive bool) byte {
+	if alive {
+		return 'O'
+	}
+	return '.'
+}
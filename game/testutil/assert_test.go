@@ -0,0 +1,104 @@
+package testutil
+
+import (
+	"fmt"
+	"gameoflife/game"
+	"strings"
+	"testing"
+)
+
+// recorder is a testing.TB that records failures instead of reporting them.
+// Fatalf stops the assertion by panicking with errFatal, which check
+// recovers from.
+type recorder struct {
+	testing.TB
+	failed bool
+	output strings.Builder
+}
+
+var errFatal = fmt.Errorf("fatal")
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failed = true
+	fmt.Fprintf(&r.output, format, args...)
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	panic(errFatal)
+}
+
+// check runs AssertGridEquals against a recorder.
+func check(g *game.Grid, ascii string) (r *recorder) {
+	r = &recorder{}
+	defer func() {
+		if err := recover(); err != nil && err != errFatal {
+			panic(err)
+		}
+	}()
+	AssertGridEquals(r, g, ascii)
+	return r
+}
+
+// blinker returns a grid with a horizontal blinker in the top-left corner.
+func blinker() *game.Grid {
+	g := new(game.Grid)
+	g[0][1], g[1][1], g[2][1] = true, true, true
+	return g
+}
+
+func TestAssertGridEqualsMatches(t *testing.T) {
+	r := check(blinker(), `
+		...
+		OOO
+	`)
+	if r.failed {
+		t.Errorf("matching grid failed the assertion:\n%s", r.output.String())
+	}
+	if r := check(blinker(), "...\n***\n..."); r.failed {
+		t.Errorf("'*' cells and trailing dead rows failed the assertion:\n%s", r.output.String())
+	}
+}
+
+func TestAssertGridEqualsReportsDiff(t *testing.T) {
+	r := check(blinker(), `
+		.O.
+		.O.
+		.O.
+	`)
+	if !r.failed {
+		t.Fatal("vertical blinker matched a horizontal one")
+	}
+	want := "grid differs from expected in 4 cells:\n" +
+		"  want   got\n" +
+		"> .O. | ...\n" +
+		"> .O. | OOO\n" +
+		"> .O. | ...\n"
+	if got := r.output.String(); got != want {
+		t.Errorf("diff = %q, want %q", got, want)
+	}
+}
+
+func TestAssertGridEqualsReportsCellsOutsideBlock(t *testing.T) {
+	g := blinker()
+	g[4][3] = true
+	r := check(g, `
+		...
+		OOO
+	`)
+	if !r.failed {
+		t.Fatal("live cell outside the block matched")
+	}
+	if got := r.output.String(); !strings.Contains(got, "in 1 cells") || !strings.Contains(got, "> ..... | ....O\n") {
+		t.Errorf("diff does not show the cell outside the block:\n%s", got)
+	}
+}
+
+func TestAssertGridEqualsRejectsBadBlock(t *testing.T) {
+	r := check(blinker(), "..x")
+	if !r.failed || !strings.Contains(r.output.String(), `unexpected character 'x'`) {
+		t.Errorf("bad character output = %q", r.output.String())
+	}
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"image"
+	"image/color"
+	"strconv"
+)
+
+// The debug font's glyphs are debugGlyphWidth by debugGlyphHeight pixels.
+const (
+	debugGlyphWidth  = 6
+	debugGlyphHeight = 16
+)
+
+// drawText draws a single line of text with its top-left corner at (x, y),
+// scaled up from the debug font by scale and tinted with clr. The glyphs are
+// printed into textBuffer, which is only replaced when the text outgrows it.
+func (g *Game) drawText(dst *ebiten.Image, text string, x, y, scale float64, clr color.Color) {
+	if text == "" {
+		return
+	}
+	width := len(text) * debugGlyphWidth
+	if g.textBuffer == nil || g.textBuffer.Bounds().Dx() < width {
+		if g.textBuffer != nil {
+			g.textBuffer.Deallocate()
+		}
+		g.textBuffer = ebiten.NewImage(width, debugGlyphHeight)
+	}
+	g.textBuffer.Clear()
+	ebitenutil.DebugPrint(g.textBuffer, text)
+	img := g.textBuffer.SubImage(image.Rect(0, 0, width, debugGlyphHeight)).(*ebiten.Image)
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(x, y)
+	op.ColorScale.ScaleWithColor(clr)
+	dst.DrawImage(img, op)
+}
+
+// contrastingColor is black or white, whichever stands out more against
+// background.
+func contrastingColor(background color.Color) color.Color {
+	r, g, b, _ := background.RGBA()
+	if 299*r+587*g+114*b > 1000*0x7fff {
+		return color.Black
+	}
+	return color.White
+}
+
+const generationCounterScale = 4
+
+// drawGenerationCounter draws the generation in large digits in the
+// top-right corner, for recordings.
+func (g *Game) drawGenerationCounter(screen *ebiten.Image) {
+	if !g.showGenerationCounter {
+		return
+	}
+	text := strconv.Itoa(g.generation)
+	width := float64(len(text) * debugGlyphWidth * generationCounterScale)
+	g.drawText(screen, text, float64(screen.Bounds().Dx())-width-16, 16, generationCounterScale, contrastingColor(g.theme().BackgroundColor))
+}
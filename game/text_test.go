@@ -0,0 +1,26 @@
+package game
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestDrawTextReusesBuffer(t *testing.T) {
+	g := NewFromOptions(Options{CellSize: 10})
+	t.Cleanup(g.Close)
+	dst := ebiten.NewImage(200, 50)
+	defer dst.Deallocate()
+
+	g.drawText(dst, "123", 0, 0, 2, color.White)
+	buffer := g.textBuffer
+	g.drawText(dst, "9", 0, 0, 2, color.White)
+	if g.textBuffer != buffer {
+		t.Error("shorter text replaced the text buffer")
+	}
+	g.drawText(dst, "12345", 0, 0, 2, color.White)
+	if g.textBuffer == buffer || g.textBuffer.Bounds().Dx() != 5*debugGlyphWidth {
+		t.Error("longer text did not get a wider text buffer")
+	}
+}
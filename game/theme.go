@@ -1,12 +1,18 @@
 package game
 
-import "image/color"
+import (
+	"image/color"
+	"slices"
+)
 
 type ThemeID int
 
 const (
 	Dark ThemeID = iota
 	Light
+	WireWorld
+	Solarized
+	HighContrast
 )
 
 type Theme struct {
@@ -14,6 +20,14 @@ type Theme struct {
 	BackgroundColor color.Color
 	GridColor       color.Color
 	CellColor       color.Color
+	BorderColor     color.Color
+	// DeadCellColor, when set, fills dead cells instead of leaving the
+	// background showing through.
+	DeadCellColor color.Color
+	CellShape     CellShape
+	// StateColors is indexed by cell state for multi-state modes; states
+	// without an entry fall back to CellColor.
+	StateColors []color.Color
 }
 
 func (t *Theme) String() string {
@@ -22,6 +36,12 @@ func (t *Theme) String() string {
 		return "Dark"
 	case Light:
 		return "Light"
+	case WireWorld:
+		return "Wire World"
+	case Solarized:
+		return "Solarized"
+	case HighContrast:
+		return "High Contrast"
 	default:
 		return "Unknown"
 	}
@@ -33,6 +53,7 @@ func NewDarkTheme() *Theme {
 		BackgroundColor: color.Gray{Y: 15},
 		GridColor:       color.Gray{Y: 31},
 		CellColor:       color.White,
+		BorderColor:     color.Gray{Y: 5},
 	}
 }
 
@@ -42,5 +63,82 @@ func NewLightTheme() *Theme {
 		BackgroundColor: color.White,
 		GridColor:       color.Gray{Y: 127},
 		CellColor:       color.Black,
+		BorderColor:     color.Gray{Y: 215},
+	}
+}
+
+func NewWireWorldTheme() *Theme {
+	return &Theme{
+		ID:              WireWorld,
+		BackgroundColor: color.Black,
+		GridColor:       color.Gray{Y: 31},
+		CellColor:       color.RGBA{R: 255, G: 200, A: 255},
+		BorderColor:     color.Gray{Y: 20},
+		StateColors: []color.Color{
+			WireEmpty:     color.Black,
+			WireConductor: color.RGBA{R: 255, G: 200, A: 255},
+			WireHead:      color.RGBA{G: 128, B: 255, A: 255},
+			WireTail:      color.RGBA{R: 255, G: 64, A: 255},
+		},
+	}
+}
+
+func NewSolarizedTheme() *Theme {
+	return &Theme{
+		ID:              Solarized,
+		BackgroundColor: color.RGBA{R: 0x00, G: 0x2b, B: 0x36, A: 0xff},
+		GridColor:       color.RGBA{R: 0x58, G: 0x6e, B: 0x75, A: 0xff},
+		CellColor:       color.RGBA{R: 0xb5, G: 0x89, B: 0x00, A: 0xff},
+		BorderColor:     color.RGBA{R: 0x00, G: 0x1e, B: 0x26, A: 0xff},
+		DeadCellColor:   color.RGBA{R: 0x07, G: 0x36, B: 0x42, A: 0xff},
+	}
+}
+
+// NewHighContrastTheme only uses black and white, plus a mid gray for the
+// grid, so that it reads the same in grayscale.
+func NewHighContrastTheme() *Theme {
+	return &Theme{
+		ID:              HighContrast,
+		BackgroundColor: color.Black,
+		GridColor:       color.Gray{Y: 128},
+		CellColor:       color.White,
+		BorderColor:     color.Gray{Y: 64},
 	}
 }
+
+func (t *Theme) stateColor(state uint8) color.Color {
+	if int(state) < len(t.StateColors) && t.StateColors[state] != nil {
+		return t.StateColors[state]
+	}
+	return t.CellColor
+}
+
+// inverted returns a copy of t with the background and cell colors swapped
+// and every other color inverted.
+func (t *Theme) inverted() *Theme {
+	inverse := *t
+	inverse.BackgroundColor, inverse.CellColor = t.CellColor, t.BackgroundColor
+	inverse.GridColor = invertColor(t.GridColor)
+	inverse.BorderColor = invertColor(t.BorderColor)
+	if t.DeadCellColor != nil {
+		inverse.DeadCellColor = invertColor(t.DeadCellColor)
+	}
+	inverse.StateColors = make([]color.Color, len(t.StateColors))
+	for state, c := range t.StateColors {
+		if c != nil {
+			inverse.StateColors[state] = invertColor(c)
+		}
+	}
+	return &inverse
+}
+
+func invertColor(c color.Color) color.Color {
+	r, g, b, a := c.RGBA()
+	return color.RGBA64{R: uint16(a - r), G: uint16(a - g), B: uint16(a - b), A: uint16(a)}
+}
+
+func (t *Theme) clone() *Theme {
+	c := *t
+	c.StateColors = slices.Clone(t.StateColors)
+	return &c
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"image/color"
+	"math"
+)
+
+const (
+	tooltipOffset  = 16
+	tooltipPadding = 4
+)
+
+var tooltipBackground = color.NRGBA{A: 192}
+
+// drawTooltip describes the cell under the cursor next to it, unless a drag
+// is in progress.
+func (g *Game) drawTooltip(screen *ebiten.Image) {
+	if g.painting || g.rectangling || g.panDragging {
+		return
+	}
+	cursorX, cursorY := ebiten.CursorPosition()
+	cellX, cellY, ok := g.cellAt(cursorX, cursorY)
+	if !ok {
+		return
+	}
+	state, neighbors := g.t("dead"), g.countLiveNeighbors(cellX, cellY)
+	if g.isAlive(cellX, cellY) {
+		state = g.t("alive")
+	}
+	switch g.mode {
+	case ModeWireWorld:
+		neighbors = g.countNeighborHeads(cellX, cellY)
+	case ModeBriansBrain:
+		neighbors = g.countNeighborsInState(cellX, cellY, BrainFiring)
+	}
+	msg := fmt.Sprintf("(%d, %d)\n%s: %s", cellX, cellY, g.t("State"), state)
+	if g.mode == ModeLife {
+		msg += fmt.Sprintf("\n%s: %d", g.t("Age"), g.CellAge(cellX, cellY))
+	}
+	msg += fmt.Sprintf("\n%s: %d", g.t("Neighbors"), neighbors)
+
+	textWidth, textHeight := text.Measure(msg, g.hudFace, g.hudLineSpacing())
+	width, height := int(math.Ceil(textWidth))+2*tooltipPadding, int(math.Ceil(textHeight))+2*tooltipPadding
+	if g.tooltip == nil || g.tooltip.Bounds().Dx() != width || g.tooltip.Bounds().Dy() != height {
+		if g.tooltip != nil {
+			g.tooltip.Deallocate()
+		}
+		g.tooltip = ebiten.NewImage(width, height)
+	}
+	g.tooltip.Fill(tooltipBackground)
+	g.drawLabel(g.tooltip, msg, tooltipPadding, tooltipPadding)
+	// Keep the tooltip on screen near the right and bottom edges.
+	x, y := cursorX+tooltipOffset, cursorY+tooltipOffset
+	if x+width > screen.Bounds().Dx() {
+		x = cursorX - tooltipOffset - width
+	}
+	if y+height > screen.Bounds().Dy() {
+		y = cursorY - tooltipOffset - height
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(g.tooltip, op)
+}
@@ -0,0 +1,61 @@
+package game
+
+type Topology int
+
+func (t Topology) String() string {
+	switch t {
+	case TopologyBounded:
+		return "Bounded"
+	case TopologyToroidal:
+		return "Toroidal"
+	default:
+		return "Unknown"
+	}
+}
+
+const (
+	TopologyBounded Topology = iota
+	TopologyToroidal
+	topologyCount
+)
+
+func (t Topology) MarshalText() ([]byte, error) {
+	return enumText(t, topologyCount)
+}
+
+func (t *Topology) UnmarshalText(text []byte) error {
+	return parseEnumText(t, text, topologyCount)
+}
+
+// neighbor offsets the cell (x, y) by (dx, dy), wrapping around the axes the
+// topology wraps. It reports false when the neighbor falls off the grid.
+func (g *Game) neighbor(x, y, dx, dy int) (int, int, bool) {
+	nx, ny := x+dx, y+dy
+	if g.wrapX {
+		nx = (nx + g.columns) % g.columns
+	}
+	if g.wrapY {
+		ny = (ny + g.rows) % g.rows
+	}
+	return nx, ny, nx >= 0 && nx < g.columns && ny >= 0 && ny < g.rows
+}
+
+func (g *Game) topologyName() string {
+	if g.gridTopology == TopologyHex {
+		return g.edgeName() + ", " + TopologyHex.String()
+	}
+	return g.edgeName()
+}
+
+func (g *Game) edgeName() string {
+	switch {
+	case g.wrapX && g.wrapY:
+		return TopologyToroidal.String()
+	case g.wrapX:
+		return "Cylinder (wraps horizontally)"
+	case g.wrapY:
+		return "Cylinder (wraps vertically)"
+	default:
+		return TopologyBounded.String()
+	}
+}
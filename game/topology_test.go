@@ -0,0 +1,45 @@
+package game_test
+
+import (
+	"gameoflife/game"
+	"gameoflife/game/testutil"
+	"slices"
+	"testing"
+)
+
+// glider is a glider heading toward the bottom-right corner.
+const glider = "bo$2bo$3o!"
+
+func TestGliderWrapsAroundToroidalEdges(t *testing.T) {
+	g := newGame(t, game.Options{Topology: game.TopologyToroidal})
+	// Four periods take the glider from the bottom-right corner of the
+	// 64x48 grid across both edges to the top-left one.
+	stamp(t, g, glider, 60, 44)
+	step(g, 16)
+	testutil.AssertGridEquals(t, g.CurrentGrid(), `
+		.O.
+		..O
+		OOO`)
+}
+
+func TestGliderOnCylinder(t *testing.T) {
+	t.Run("wraps on the wrapping axis", func(t *testing.T) {
+		g := newGame(t, game.Options{WrapX: true})
+		stamp(t, g, glider, 60, 20)
+		step(g, 16)
+		want := [][2]int{{0, 26}, {1, 24}, {1, 26}, {2, 25}, {2, 26}}
+		if got := g.CurrentGrid().LiveCells(); !slices.Equal(got, want) {
+			t.Errorf("live cells = %v, want the glider at %v", got, want)
+		}
+	})
+	t.Run("dies at the bounded edge", func(t *testing.T) {
+		g := newGame(t, game.Options{WrapX: true})
+		stamp(t, g, glider, 20, 42)
+		// The glider crashes into the bottom edge and leaves a block.
+		step(g, 24)
+		want := [][2]int{{24, 46}, {24, 47}, {25, 46}, {25, 47}}
+		if got := g.CurrentGrid().LiveCells(); !slices.Equal(got, want) {
+			t.Errorf("live cells = %v, want a block at %v", got, want)
+		}
+	})
+}
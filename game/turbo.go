@@ -0,0 +1,62 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// turboFrameBudget is how long turbo mode cycles per frame, leaving the rest
+// of a 60 FPS frame for input and drawing.
+const turboFrameBudget = 15 * time.Millisecond
+
+// rateMeter measures how often something happens per second, averaged over
+// one second windows.
+type rateMeter struct {
+	start time.Time
+	count int
+	rate  float64
+}
+
+func (m *rateMeter) add(n int) {
+	m.count += n
+}
+
+func (m *rateMeter) update(now time.Time) {
+	if elapsed := now.Sub(m.start); elapsed >= time.Second {
+		m.rate = float64(m.count) / elapsed.Seconds()
+		m.start, m.count = now, 0
+	}
+}
+
+// turboActive reports whether Update should cycle for the whole frame
+// budget. The rule preview needs the generation to hold still, so it turns
+// turbo mode off.
+func (g *Game) turboActive() bool {
+	return g.turboMode && g.state == Running && !g.previewing
+}
+
+// runTurbo advances as many generations as fit in the frame budget, or until
+// auto-pause stops the game. The ticks counted meanwhile are dropped, so
+// turning turbo mode off does not make the game catch up on them.
+func (g *Game) runTurbo() {
+	start := time.Now()
+	for g.state == Running && time.Since(start) <= turboFrameBudget {
+		g.advance()
+	}
+	g.ticks = 0
+	g.lastGenerationAt = time.Now()
+}
+
+// siPrefixed formats a rate with a k, M or G suffix, e.g. 1.2M.
+func siPrefixed(rate float64) string {
+	switch {
+	case rate >= 1e9:
+		return fmt.Sprintf("%.1fG", rate/1e9)
+	case rate >= 1e6:
+		return fmt.Sprintf("%.1fM", rate/1e6)
+	case rate >= 1e3:
+		return fmt.Sprintf("%.1fk", rate/1e3)
+	default:
+		return fmt.Sprintf("%.0f", rate)
+	}
+}
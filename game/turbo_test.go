@@ -0,0 +1,25 @@
+package game
+
+import "testing"
+
+// TestTurboAdvancesGenerations checks that turbo mode, the max speed mode,
+// runs generations through advance so auto-pause still stops it, and that
+// it drops the ticks counted meanwhile.
+func TestTurboAdvancesGenerations(t *testing.T) {
+	g := NewFromOptions(Options{CellSize: 10, AutoPause: true})
+	t.Cleanup(g.Close)
+	for _, cell := range [][2]int{{10, 10}, {11, 10}, {10, 11}, {11, 11}} {
+		g.currentGrid[cell[0]][cell[1]] = true
+	}
+	g.state, g.turboMode, g.ticks = Running, true, 5*g.ticksPerGeneration
+	g.runTurbo()
+	if g.state == Running {
+		t.Fatal("turbo mode ran past a still life with auto-pause on")
+	}
+	if g.generation != 2 {
+		t.Errorf("turbo mode paused at generation %d, want 2", g.generation)
+	}
+	if g.ticks != 0 {
+		t.Errorf("turbo mode left %g ticks", g.ticks)
+	}
+}
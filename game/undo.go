@@ -0,0 +1,88 @@
+package game
+
+import (
+	"bytes"
+	"time"
+)
+
+const (
+	// undoCoalesceWindow is how soon an edit has to follow the previous one
+	// to be undone together with it, so that a painting drag is one step.
+	undoCoalesceWindow = 500 * time.Millisecond
+	// maxUndoSteps is how many snapshots Undo keeps.
+	maxUndoSteps = 50
+)
+
+// undoSnapshot holds the grid as packed by MarshalGrid, which keeps the
+// snapshots small now that the storage has room for one pixel cells. The
+// Wire World states are only kept in that mode.
+type undoSnapshot struct {
+	grid           []byte
+	states         *[maxColumns][maxRows]uint8
+	elementarySeed [maxColumns]bool
+}
+
+func (s undoSnapshot) equal(other undoSnapshot) bool {
+	if !bytes.Equal(s.grid, other.grid) || s.elementarySeed != other.elementarySeed || (s.states == nil) != (other.states == nil) {
+		return false
+	}
+	return s.states == nil || *s.states == *other.states
+}
+
+// recordEdit is called before a cell edit. It snapshots the cells unless the
+// previous edit was less than undoCoalesceWindow ago, with no generation or
+// reset in between.
+func (g *Game) recordEdit() {
+	now := time.Now()
+	if g.lastEditTime.IsZero() || now.Sub(g.lastEditTime) > undoCoalesceWindow {
+		g.pushUndo()
+	}
+	g.lastEditTime = now
+}
+
+// pushUndo snapshots the cells, unless they have not changed since the last
+// snapshot, and ends the current run of coalesced edits.
+func (g *Game) pushUndo() {
+	g.lastEditTime = time.Time{}
+	snapshot := undoSnapshot{grid: g.MarshalGrid(), elementarySeed: g.elementarySeed}
+	if g.multiState() {
+		states := *g.states
+		snapshot.states = &states
+	}
+	if n := len(g.undoStack); n > 0 && g.undoStack[n-1].equal(snapshot) {
+		return
+	}
+	if len(g.undoStack) == maxUndoSteps {
+		g.undoStack = g.undoStack[1:]
+	}
+	g.undoStack = append(g.undoStack, snapshot)
+}
+
+// Undo restores the cells as they were before the last edit, run of edits
+// or reset. It reports false when there is nothing to undo.
+func (g *Game) Undo() bool {
+	n := len(g.undoStack)
+	if n == 0 {
+		return false
+	}
+	snapshot := g.undoStack[n-1]
+	g.undoStack = g.undoStack[:n-1]
+	// The grid may have been resized since the snapshot, which then only
+	// covers part of it.
+	columns, rows, _ := marshaledGridSize(snapshot.grid)
+	for j := 0; j < g.rows; j++ {
+		for i := 0; i < g.columns; i++ {
+			alive := i < columns && j < rows && marshaledCell(snapshot.grid, columns, i, j)
+			if g.currentGrid[i][j] != alive {
+				g.currentGrid[i][j] = alive
+				g.ages[i][j] = 0
+			}
+		}
+	}
+	if snapshot.states != nil {
+		*g.states = *snapshot.states
+	}
+	g.elementarySeed = snapshot.elementarySeed
+	g.lastEditTime = time.Time{}
+	return true
+}
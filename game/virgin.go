@@ -0,0 +1,80 @@
+package game
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"image/color"
+)
+
+// virginCellColor tints the theme's dead cell color slightly blue to mark
+// cells that have never been alive.
+func virginCellColor(t *Theme) color.Color {
+	base := t.BackgroundColor
+	if t.DeadCellColor != nil {
+		base = t.DeadCellColor
+	}
+	r, g, b, _ := base.RGBA()
+	return color.RGBA{
+		R: uint8(r >> 8 * 7 / 8),
+		G: uint8(g >> 8 * 7 / 8),
+		B: uint8(min(b>>8+40, 0xff)),
+		A: 0xff,
+	}
+}
+
+// drawVirginCells marks the cells that have never been alive since the last
+// reset.
+func (g *Game) drawVirginCells(dst *ebiten.Image) {
+	if !g.showVirginCells || g.mode != ModeLife {
+		return
+	}
+	key := spriteKey{shape: CellShapeRect, color: virginCellColor(g.theme())}
+	size := g.drawCellSize()
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			if g.everAlive[i][j] || g.currentGrid[i][j] {
+				continue
+			}
+			if g.gridTopology == TopologyHex {
+				x, y := g.hexOrigin(i, j)
+				g.drawSprite(dst, x, y, spriteKey{hex: true, color: key.color})
+			} else {
+				g.drawSprite(dst, float32(i)*size, float32(j)*size, key)
+			}
+		}
+	}
+}
+
+var (
+	deadZoneColor  = color.NRGBA{R: 0x30, G: 0x50, B: 0xc0, A: 0x60}
+	aliveZoneColor = color.NRGBA{R: 0xc0, G: 0x30, B: 0x30, A: 0x60}
+)
+
+// drawZoneHighlight tints the cells that have not changed state since the
+// last reset: blue for those that were always dead, red for those that were
+// always alive.
+func (g *Game) drawZoneHighlight(dst *ebiten.Image) {
+	if !g.showZoneHighlight || g.mode != ModeLife {
+		return
+	}
+	size := g.drawCellSize()
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			alive := g.currentGrid[i][j]
+			var c color.Color
+			switch {
+			case !alive && !g.everAlive[i][j]:
+				c = deadZoneColor
+			case alive && !g.everDead[i][j]:
+				c = aliveZoneColor
+			default:
+				continue
+			}
+			if g.gridTopology == TopologyHex {
+				x, y := g.hexOrigin(i, j)
+				g.drawSprite(dst, x, y, spriteKey{hex: true, color: c})
+			} else {
+				g.drawSprite(dst, float32(i)*size, float32(j)*size, spriteKey{shape: CellShapeRect, color: c})
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package game
+
+const (
+	WireEmpty uint8 = iota
+	WireConductor
+	WireHead
+	WireTail
+	wireStates
+)
+
+func (g *Game) cycleWireWorld() {
+	newStates := g.nextStates()
+	for i := 0; i < g.columns; i++ {
+		for j := 0; j < g.rows; j++ {
+			switch g.states[i][j] {
+			case WireHead:
+				newStates[i][j] = WireTail
+			case WireTail:
+				newStates[i][j] = WireConductor
+			case WireConductor:
+				count := g.countNeighborHeads(i, j)
+				if count == 1 || count == 2 {
+					newStates[i][j] = WireHead
+				} else {
+					newStates[i][j] = WireConductor
+				}
+			default:
+				newStates[i][j] = WireEmpty
+			}
+		}
+	}
+	g.states = newStates
+}
+
+func (g *Game) countNeighborHeads(x, y int) int {
+	count := 0
+	for i := -1; i <= 1; i++ {
+		for j := -1; j <= 1; j++ {
+			if i == 0 && j == 0 {
+				continue
+			}
+			if nx, ny, ok := g.neighbor(x, y, i, j); ok {
+				if g.states[nx][ny] == WireHead {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
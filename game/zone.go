@@ -0,0 +1,54 @@
+package game
+
+import (
+	"fmt"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Zone applies its own Life rule to the cells of a rectangle instead of the
+// game's rule.
+type Zone struct {
+	X, Y, W, H int
+	Rule       Rule
+}
+
+// AddZone adds z on top of the zones added before it, so it wins where they
+// overlap. The parts of z outside the grid are ignored. Its rule must suit
+// the grid topology, as for Options.Rule.
+func (g *Game) AddZone(z Zone) error {
+	if err := g.gridTopology.checkRule(z.Rule); err != nil {
+		return fmt.Errorf("invalid zone rule: %w", err)
+	}
+	g.zones = append(g.zones, z)
+	g.zoneTables = append(g.zoneTables, precomputeRuleTable(z.Rule))
+	for i := max(z.X, 0); i < min(z.X+z.W, maxColumns); i++ {
+		for j := max(z.Y, 0); j < min(z.Y+z.H, maxRows); j++ {
+			g.zoneOf[i][j] = uint16(len(g.zones))
+		}
+	}
+	return nil
+}
+
+// ruleTableAt is the rule table for the cell at (x, y), taking zones into
+// account.
+func (g *Game) ruleTableAt(x, y int) *ruleTable {
+	if zone := g.zoneOf[x][y]; zone != 0 {
+		return &g.zoneTables[zone-1]
+	}
+	return &g.ruleTable
+}
+
+func (g *Game) ruleAt(x, y int) Rule {
+	if zone := g.zoneOf[x][y]; zone != 0 {
+		return g.zones[zone-1].Rule
+	}
+	return g.rule
+}
+
+func (g *Game) drawZones(dst *ebiten.Image) {
+	size := g.drawCellSize()
+	for i, z := range g.zones {
+		vector.StrokeRect(dst, float32(z.X)*size, float32(z.Y)*size, float32(z.W)*size, float32(z.H)*size, 2, componentPalette[i%len(componentPalette)], true)
+	}
+}
@@ -0,0 +1,20 @@
+package game_test
+
+import (
+	"gameoflife/game"
+	"testing"
+)
+
+func TestAddZoneChecksRuleAgainstTopology(t *testing.T) {
+	hex := newGame(t, game.Options{GridTopology: game.TopologyHex})
+	if err := hex.AddZone(game.Zone{W: 10, H: 10, Rule: game.Conway}); err == nil {
+		t.Error("hex grid accepted a zone with an 8-neighbor rule")
+	}
+	if err := hex.AddZone(game.Zone{W: 10, H: 10, Rule: game.HexRule}); err != nil {
+		t.Errorf("hex grid rejected a zone with a hex rule: %v", err)
+	}
+	square := newGame(t, game.Options{})
+	if err := square.AddZone(game.Zone{W: 10, H: 10, Rule: game.HighLife}); err != nil {
+		t.Errorf("square grid rejected a zone with %s: %v", game.HighLife, err)
+	}
+}